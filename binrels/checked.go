@@ -0,0 +1,143 @@
+package binrels
+
+// The checked functions validate their input before delegating to the
+// unchecked ones. An empty relation is valid input and comes back as nil
+// with a nil error, so nil results no longer hide mistakes.
+
+func CopyChecked(a [][]bool) ([][]bool, error) {
+	if _, _, err := checkShape("Copy", a); err != nil {
+		return nil, err
+	}
+	return Copy(a), nil
+}
+
+func binaryChecked(op string, a, b [][]bool, f func(a, b [][]bool) [][]bool) ([][]bool, error) {
+	if err := checkSameShape(op, a, b); err != nil {
+		return nil, err
+	}
+	return f(a, b), nil
+}
+
+func UnionChecked(a, b [][]bool) ([][]bool, error) {
+	return binaryChecked("Union", a, b, Union)
+}
+
+func IntersectionChecked(a, b [][]bool) ([][]bool, error) {
+	return binaryChecked("Intersection", a, b, Intersection)
+}
+
+func DiffChecked(a, b [][]bool) ([][]bool, error) {
+	return binaryChecked("Diff", a, b, Diff)
+}
+
+func SymmDiffChecked(a, b [][]bool) ([][]bool, error) {
+	return binaryChecked("SymmDiff", a, b, SymmDiff)
+}
+
+func CompositionChecked(a, b [][]bool) ([][]bool, error) {
+	_, colsA, err := checkShape("Composition", a)
+	if err != nil {
+		return nil, err
+	}
+	rowsB, _, err := checkShape("Composition", b)
+	if err != nil {
+		return nil, err
+	}
+	if colsA != rowsB {
+		return nil, newError("Composition", ErrDimensionMismatch, "left has %d columns, right has %d rows", colsA, rowsB)
+	}
+	return Composition(a, b), nil
+}
+
+func PowerChecked(a [][]bool, n int) ([][]bool, error) {
+	if _, err := checkSquare("Power", a); err != nil {
+		return nil, err
+	}
+	if n < 0 {
+		return nil, newError("Power", ErrNegativePower, "%d", n)
+	}
+	return Copy(Power(a, n)), nil
+}
+
+func TransposeChecked(a [][]bool) ([][]bool, error) {
+	if _, _, err := checkShape("Transpose", a); err != nil {
+		return nil, err
+	}
+	return Transpose(a), nil
+}
+
+func ComplementChecked(a [][]bool) ([][]bool, error) {
+	if _, _, err := checkShape("Complement", a); err != nil {
+		return nil, err
+	}
+	return Complement(a), nil
+}
+
+func DefinitionDomainChecked(a [][]bool) ([]int, error) {
+	if _, _, err := checkShape("DefinitionDomain", a); err != nil {
+		return nil, err
+	}
+	return DefinitionDomain(a), nil
+}
+
+func MeaningDomainChecked(a [][]bool) ([]int, error) {
+	if _, _, err := checkShape("MeaningDomain", a); err != nil {
+		return nil, err
+	}
+	return MeaningDomain(a), nil
+}
+
+func BottomIntersectionChecked(a [][]bool, x int) ([]int, error) {
+	rows, _, err := checkShape("BottomIntersection", a)
+	if err != nil {
+		return nil, err
+	}
+	if x < 0 || x >= rows {
+		return nil, newError("BottomIntersection", ErrIndexOutOfRange, "row %d of %d", x, rows)
+	}
+	return BottomIntersection(a, x), nil
+}
+
+func TopIntersectionChecked(a [][]bool, x int) ([]int, error) {
+	_, cols, err := checkShape("TopIntersection", a)
+	if err != nil {
+		return nil, err
+	}
+	if x < 0 || x >= cols {
+		return nil, newError("TopIntersection", ErrIndexOutOfRange, "column %d of %d", x, cols)
+	}
+	return TopIntersection(a, x), nil
+}
+
+func TransitiveClosureChecked(a [][]bool) ([][]bool, error) {
+	if _, err := checkSquare("TransitiveClosure", a); err != nil {
+		return nil, err
+	}
+	return TransitiveClosure(a), nil
+}
+
+func ReachabilityChecked(a [][]bool) ([][]bool, error) {
+	if _, err := checkSquare("Reachability", a); err != nil {
+		return nil, err
+	}
+	return Reachability(a), nil
+}
+
+func MutualReachabilityChecked(a [][]bool) ([][]bool, error) {
+	if _, err := checkSquare("MutualReachability", a); err != nil {
+		return nil, err
+	}
+	return MutualReachability(a), nil
+}
+
+func PrintWithSourceChecked(source []string, relationship [][]bool) error {
+	n, err := checkSquare("PrintWithSource", relationship)
+	if err != nil {
+		return err
+	}
+	if len(source) != n {
+		return newError("PrintWithSource", ErrDimensionMismatch, "%d names for %d elements", len(source), n)
+	}
+	PrintWithSource(source, relationship)
+	return nil
+}
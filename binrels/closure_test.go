@@ -0,0 +1,96 @@
+package binrels
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+// naiveComposition is the triple loop Composition used before the packed
+// kernels.
+func naiveComposition(a, b [][]bool) [][]bool {
+	return foreachcell(len(a), len(b[0]), func(i int, j int) bool {
+		for k := range b {
+			if a[i][k] && b[k][j] {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func naivePower(a [][]bool, n int) [][]bool {
+	if n == 0 {
+		return Identity(len(a))
+	}
+	if n == 1 {
+		return a
+	}
+	if n&1 == 0 {
+		half := naivePower(a, n/2)
+		return naiveComposition(half, half)
+	}
+	return naiveComposition(a, naivePower(a, n-1))
+}
+
+// powerClosure is the old TransitiveClosure: the union of ever higher
+// powers, each computed from scratch, until it stops growing.
+func powerClosure(a [][]bool) [][]bool {
+	accumulator := Copy(a)
+	for i := 2; ; i++ {
+		before := Copy(accumulator)
+		accumulator = Union(accumulator, naivePower(a, i))
+		if Equal(before, accumulator) {
+			return accumulator
+		}
+	}
+}
+
+func randomMatrix(rng *rand.Rand, rows, cols int, density float64) [][]bool {
+	a := ZeroRect(rows, cols)
+	for i := range a {
+		for j := range a[i] {
+			a[i][j] = rng.Float64() < density
+		}
+	}
+	return a
+}
+
+func TestTransitiveClosureMatchesPowerIteration(t *testing.T) {
+	rng := rand.New(rand.NewPCG(3, 3))
+	for trial := 0; trial < 200; trial++ {
+		n := 1 + rng.IntN(40)
+		a := randomMatrix(rng, n, n, 1.5/float64(n))
+
+		want := powerClosure(a)
+		if got := TransitiveClosure(a); !Equal(got, want) {
+			t.Fatalf("TransitiveClosure of %v = %v, want %v", a, got, want)
+		}
+		if got, want := Reachability(a), Union(Identity(n), want); !Equal(got, want) {
+			t.Fatalf("Reachability of %v = %v, want %v", a, got, want)
+		}
+		if got, want := MutualReachability(a), Intersection(Reachability(a), Transpose(Reachability(a))); !Equal(got, want) {
+			t.Fatalf("MutualReachability of %v = %v, want %v", a, got, want)
+		}
+	}
+}
+
+func BenchmarkTransitiveClosure(b *testing.B) {
+	for _, n := range []int{16, 64, 128} {
+		rng := rand.New(rand.NewPCG(uint64(n), 3))
+		// Sparse relations have long chains, which the old path pays for with
+		// one power per step.
+		a := randomMatrix(rng, n, n, 1.5/float64(n))
+
+		b.Run(fmt.Sprintf("power/n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				powerClosure(a)
+			}
+		})
+		b.Run(fmt.Sprintf("warshall/n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				TransitiveClosure(a)
+			}
+		})
+	}
+}
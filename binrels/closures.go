@@ -0,0 +1,58 @@
+package binrels
+
+import "fmt"
+
+func ReflexiveClosure(a [][]bool) [][]bool {
+	return Union(a, Identity(len(a)))
+}
+
+func SymmetricClosure(a [][]bool) [][]bool {
+	return Union(a, Transpose(a))
+}
+
+func ReflexiveSymmetricClosure(a [][]bool) [][]bool {
+	return ReflexiveClosure(SymmetricClosure(a))
+}
+
+// EquivalenceClosure returns the smallest equivalence relation containing a.
+func EquivalenceClosure(a [][]bool) [][]bool {
+	return Reachability(SymmetricClosure(a))
+}
+
+// Closure returns the smallest relation containing a that has every one of
+// props. Only reflexivity, symmetry and transitivity are closure properties;
+// asking for anything else is an error. For example, Reflexive and
+// Transitive together give the smallest preorder containing a.
+func Closure(a [][]bool, props ...Property) ([][]bool, error) {
+	var reflexive, symmetric, transitive bool
+	for _, p := range props {
+		switch p {
+		case Reflexive:
+			reflexive = true
+		case Symmetric:
+			symmetric = true
+		case Transitive:
+			transitive = true
+		default:
+			return nil, fmt.Errorf("%s is not a closure property", p)
+		}
+	}
+
+	if _, err := checkSquare("Closure", a); err != nil {
+		return nil, err
+	}
+
+	// Symmetry has to come first: the transitive closure of a symmetric
+	// relation stays symmetric, and adding loops breaks neither.
+	result := Copy(a)
+	if symmetric {
+		result = SymmetricClosure(result)
+	}
+	if transitive {
+		result = TransitiveClosure(result)
+	}
+	if reflexive {
+		result = ReflexiveClosure(result)
+	}
+	return result, nil
+}
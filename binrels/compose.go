@@ -0,0 +1,67 @@
+package binrels
+
+import "math/bits"
+
+// russianBits is the number of rows of the right operand that the Four
+// Russians kernel combines into one lookup table. It divides wordBits, so a
+// group of columns never straddles two words of a row.
+const russianBits = 8
+
+// composeRows ORs row k of s into row i of dst for every pair (i, k) of r,
+// so the work is proportional to the number of pairs times the words per
+// row.
+func composeRows(dst, r, s *Relation) {
+	for i := 0; i < r.rows; i++ {
+		composeRow(dst, r, s, i)
+	}
+}
+
+func composeRow(dst, r, s *Relation, i int) {
+	row := dst.row(i)
+	r.eachInRow(i, func(k int) {
+		orInto(row, s.row(k))
+	})
+}
+
+// composeFourRussians implements the Method of Four Russians: the rows of s
+// are taken russianBits at a time, every possible OR of such a group is
+// tabulated once, and each row of r then needs a single table lookup per
+// group instead of one OR per pair.
+func composeFourRussians(dst, r, s *Relation) {
+	table := make([]uint64, (1<<russianBits)*s.stride)
+	entry := func(mask int) []uint64 {
+		return table[mask*s.stride : (mask+1)*s.stride]
+	}
+
+	for base := 0; base < r.cols; base += russianBits {
+		group := min(russianBits, r.cols-base)
+
+		// Every mask extends the mask without its lowest bit by one row.
+		for mask := 1; mask < 1<<group; mask++ {
+			low := mask & -mask
+			k := base + bits.TrailingZeros(uint(low))
+			e := entry(mask)
+			copy(e, entry(mask^low))
+			orInto(e, s.row(k))
+		}
+
+		word, shift := base/wordBits, base%wordBits
+		for i := 0; i < r.rows; i++ {
+			mask := int(r.row(i)[word]>>shift) & (1<<group - 1)
+			if mask != 0 {
+				orInto(dst.row(i), entry(mask))
+			}
+		}
+	}
+}
+
+// preferFourRussians compares the word operations of both kernels. The
+// row-OR kernel pays per pair of r, the Four Russians kernel per group of
+// columns for building its table and for one lookup in every row, so it
+// wins once r is dense enough.
+func preferFourRussians(r, s *Relation) bool {
+	groups := (r.cols + russianBits - 1) / russianBits
+	rowsCost := r.Count() * s.stride
+	russianCost := groups * (1<<russianBits + r.rows) * s.stride
+	return russianCost < rowsCost
+}
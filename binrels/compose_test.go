@@ -0,0 +1,55 @@
+package binrels
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// composeSizes mixes dimensions that fill a whole number of Four Russians
+// groups or words with ones that leave a partial group or word.
+var composeSizes = []int{1, 3, 7, 8, 9, 63, 64, 65, 100, 130}
+
+func TestComposeKernelsMatchNaive(t *testing.T) {
+	rng := rand.New(rand.NewPCG(21, 21))
+	kernels := []struct {
+		name    string
+		compose func(dst, r, s *Relation)
+	}{
+		{"composeRows", composeRows},
+		{"composeFourRussians", composeFourRussians},
+	}
+
+	for trial := 0; trial < 300; trial++ {
+		rows := composeSizes[rng.IntN(len(composeSizes))]
+		mid := composeSizes[rng.IntN(len(composeSizes))]
+		cols := composeSizes[rng.IntN(len(composeSizes))]
+		density := rng.Float64()
+		a := randomMatrix(rng, rows, mid, density)
+		b := randomMatrix(rng, mid, cols, density)
+		want := naiveComposition(a, b)
+
+		for _, k := range kernels {
+			dst := newRelation(rows, cols)
+			k.compose(dst, FromMatrix(a), FromMatrix(b))
+			if got := dst.Matrix(); !Equal(got, want) {
+				t.Fatalf("%s differs from naive composition for %dx%d ∘ %dx%d at density %.2f", k.name, rows, mid, mid, cols, density)
+			}
+		}
+
+		if got := Composition(a, b); !Equal(got, want) {
+			t.Fatalf("Composition differs from naive composition for %dx%d ∘ %dx%d at density %.2f", rows, mid, mid, cols, density)
+		}
+	}
+}
+
+func TestPowerMatchesNaive(t *testing.T) {
+	rng := rand.New(rand.NewPCG(21, 7))
+	for trial := 0; trial < 100; trial++ {
+		n := composeSizes[rng.IntN(len(composeSizes))]
+		a := randomMatrix(rng, n, n, 2/float64(n))
+		k := rng.IntN(10)
+		if got, want := Power(a, k), naivePower(a, k); !Equal(got, want) {
+			t.Fatalf("Power(a, %d) differs from naive power for n = %d", k, n)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package binrels
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Context is a formal context: a relation between objects, the rows, and
+// attributes, the columns.
+type Context struct {
+	incidence [][]bool
+	objects   int
+	attrs     int
+}
+
+func NewContext(a [][]bool) (*Context, error) {
+	rows, cols, err := checkShape("NewContext", a)
+	if err != nil {
+		return nil, err
+	}
+	return &Context{incidence: Copy(a), objects: rows, attrs: cols}, nil
+}
+
+func (c *Context) Objects() int {
+	return c.objects
+}
+
+func (c *Context) Attributes() int {
+	return c.attrs
+}
+
+func (c *Context) Matrix() [][]bool {
+	return Copy(c.incidence)
+}
+
+// Intent returns the attributes shared by all of objects, written A′. It
+// returns nil when objects names an unknown object.
+func (c *Context) Intent(objects []int) []int {
+	if !inBounds(objects, c.objects) {
+		return nil
+	}
+	return common(c.incidence, objects, c.attrs, BottomIntersection)
+}
+
+// Extent returns the objects having all of attributes, written B′. It
+// returns nil when attributes names an unknown attribute.
+func (c *Context) Extent(attributes []int) []int {
+	if !inBounds(attributes, c.attrs) {
+		return nil
+	}
+	return common(c.incidence, attributes, c.objects, TopIntersection)
+}
+
+func inBounds(set []int, n int) bool {
+	for _, x := range set {
+		if x < 0 || x >= n {
+			return false
+		}
+	}
+	return true
+}
+
+// common intersects the rows or columns that side picks out of a for each
+// element of set, out of n candidates.
+func common(a [][]bool, set []int, n int, side func(a [][]bool, x int) []int) []int {
+	hits := make([]int, n)
+	for _, x := range set {
+		for _, y := range side(a, x) {
+			hits[y]++
+		}
+	}
+
+	res := make([]int, 0)
+	for y, h := range hits {
+		if h == len(set) {
+			res = append(res, y)
+		}
+	}
+	return res
+}
+
+// closure returns B″ for a set of attributes given as a membership mask.
+func (c *Context) closure(attrs []bool) []bool {
+	return mask(c.Intent(c.Extent(members(attrs))), c.attrs)
+}
+
+func members(set []bool) []int {
+	res := make([]int, 0)
+	for x, in := range set {
+		if in {
+			res = append(res, x)
+		}
+	}
+	return res
+}
+
+func mask(set []int, n int) []bool {
+	res := make([]bool, n)
+	for _, x := range set {
+		res[x] = true
+	}
+	return res
+}
+
+// nextClosure returns the lectically next set after set that is closed under
+// closure, in Ganter's order where lower indices weigh more. It reports false
+// after the last one.
+func nextClosure(set []bool, closure func([]bool) []bool) ([]bool, bool) {
+	for i := len(set) - 1; i >= 0; i-- {
+		if set[i] {
+			continue
+		}
+
+		candidate := make([]bool, len(set))
+		copy(candidate, set[:i])
+		candidate[i] = true
+		candidate = closure(candidate)
+
+		if slices.Equal(candidate[:i], set[:i]) {
+			return candidate, true
+		}
+	}
+	return nil, false
+}
+
+type Concept struct {
+	Extent []int
+	Intent []int
+}
+
+// Concepts enumerates the formal concepts of c with NextClosure, in lectic
+// order of their intents, which starts with the concept of all objects.
+func (c *Context) Concepts() []Concept {
+	res := make([]Concept, 0)
+	intent := c.closure(make([]bool, c.attrs))
+	for ok := true; ok; intent, ok = nextClosure(intent, c.closure) {
+		attrs := members(intent)
+		res = append(res, Concept{Extent: c.Extent(attrs), Intent: attrs})
+	}
+	return res
+}
+
+// Lattice returns the concepts of c together with their order: concept i is
+// below concept j when its extent is contained in the extent of j. The order
+// can be passed to NewPoset.
+func (c *Context) Lattice() ([]Concept, [][]bool) {
+	concepts := c.Concepts()
+	extents := make([][]bool, len(concepts))
+	for i, concept := range concepts {
+		extents[i] = mask(concept.Extent, c.objects)
+	}
+
+	order := foreachcell(len(concepts), len(concepts), func(i int, j int) bool {
+		for x, in := range extents[i] {
+			if in && !extents[j][x] {
+				return false
+			}
+		}
+		return true
+	})
+	return concepts, order
+}
+
+// Implication says that objects with every attribute of Premise also have
+// every attribute of Conclusion.
+type Implication struct {
+	Premise    []int
+	Conclusion []int
+}
+
+func (im Implication) String() string {
+	return formatSet(im.Premise) + " -> " + formatSet(im.Conclusion)
+}
+
+func formatSet(set []int) string {
+	parts := make([]string, len(set))
+	for i, x := range set {
+		parts[i] = strconv.Itoa(x)
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// applyProper closes set under the implications whose premise is a proper
+// subset of it. The sets closed this way are the intents and pseudo-intents.
+func applyProper(set []bool, basis []Implication) []bool {
+	res := slices.Clone(set)
+	for changed := true; changed; {
+		changed = false
+		for _, im := range basis {
+			if !properSubset(im.Premise, res) {
+				continue
+			}
+			for _, x := range im.Conclusion {
+				if !res[x] {
+					res[x] = true
+					changed = true
+				}
+			}
+		}
+	}
+	return res
+}
+
+func properSubset(premise []int, set []bool) bool {
+	for _, x := range premise {
+		if !set[x] {
+			return false
+		}
+	}
+	return len(premise) < len(members(set))
+}
+
+// StemBase returns the Duquenne–Guigues basis of the attribute implications
+// holding in c: the smallest set of implications from which all others
+// follow. Premises are the pseudo-intents in lectic order and each
+// conclusion lists only the attributes the premise does not already have.
+func (c *Context) StemBase() []Implication {
+	basis := make([]Implication, 0)
+	closure := func(set []bool) []bool {
+		return applyProper(set, basis)
+	}
+
+	set := make([]bool, c.attrs)
+	for ok := true; ok; set, ok = nextClosure(set, closure) {
+		closed := c.closure(set)
+		if slices.Equal(closed, set) {
+			continue
+		}
+
+		var added []int
+		for x := range closed {
+			if closed[x] && !set[x] {
+				added = append(added, x)
+			}
+		}
+		basis = append(basis, Implication{Premise: members(set), Conclusion: added})
+	}
+	return basis
+}
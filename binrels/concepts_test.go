@@ -0,0 +1,149 @@
+package binrels
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// subsets lists every subset of n elements as a membership mask.
+func subsets(n int) [][]bool {
+	res := make([][]bool, 0, 1<<n)
+	for bits := 0; bits < 1<<n; bits++ {
+		set := make([]bool, n)
+		for x := range set {
+			set[x] = bits&(1<<x) != 0
+		}
+		res = append(res, set)
+	}
+	return res
+}
+
+func isSubset(a, b []bool) bool {
+	for x := range a {
+		if a[x] && !b[x] {
+			return false
+		}
+	}
+	return true
+}
+
+// follow closes set under every implication of basis.
+func follow(set []bool, basis []Implication) []bool {
+	res := slices.Clone(set)
+	for changed := true; changed; {
+		changed = false
+		for _, im := range basis {
+			if !isSubset(mask(im.Premise, len(set)), res) {
+				continue
+			}
+			for _, x := range im.Conclusion {
+				if !res[x] {
+					res[x] = true
+					changed = true
+				}
+			}
+		}
+	}
+	return res
+}
+
+func randomContext(t *testing.T, rng *rand.Rand) *Context {
+	t.Helper()
+	c, err := NewContext(randomMatrix(rng, rng.IntN(7), 1+rng.IntN(6), rng.Float64()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestGaloisConnection(t *testing.T) {
+	rng := rand.New(rand.NewPCG(25, 1))
+	for trial := 0; trial < 200; trial++ {
+		c := randomContext(t, rng)
+		for _, attrs := range subsets(c.Attributes()) {
+			extent := mask(c.Extent(members(attrs)), c.Objects())
+			if !isSubset(attrs, mask(c.Intent(members(extent)), c.Attributes())) {
+				t.Fatalf("%v is not contained in its closure in %v", members(attrs), c.Matrix())
+			}
+			for _, fewer := range subsets(c.Attributes()) {
+				if isSubset(fewer, attrs) && !isSubset(extent, mask(c.Extent(members(fewer)), c.Objects())) {
+					t.Fatalf("Extent is not antitone on %v ⊆ %v in %v", members(fewer), members(attrs), c.Matrix())
+				}
+			}
+		}
+	}
+}
+
+func TestConceptsMatchClosedSets(t *testing.T) {
+	rng := rand.New(rand.NewPCG(25, 2))
+	for trial := 0; trial < 200; trial++ {
+		c := randomContext(t, rng)
+
+		closed := make(map[string]bool)
+		for _, attrs := range subsets(c.Attributes()) {
+			if slices.Equal(c.closure(attrs), attrs) {
+				closed[formatSet(members(attrs))] = true
+			}
+		}
+
+		concepts := c.Concepts()
+		if len(concepts) != len(closed) {
+			t.Fatalf("%d concepts of %v, want %d", len(concepts), c.Matrix(), len(closed))
+		}
+		for i, concept := range concepts {
+			if !closed[formatSet(concept.Intent)] {
+				t.Fatalf("intent %v of %v is not closed", concept.Intent, c.Matrix())
+			}
+			if !slices.Equal(concept.Extent, c.Extent(concept.Intent)) || !slices.Equal(concept.Intent, c.Intent(concept.Extent)) {
+				t.Fatalf("%v is not a concept of %v", concept, c.Matrix())
+			}
+			if i > 0 && !lecticLess(concepts[i-1].Intent, concept.Intent, c.Attributes()) {
+				t.Fatalf("intents %v and %v of %v are out of lectic order", concepts[i-1].Intent, concept.Intent, c.Matrix())
+			}
+		}
+
+		_, order := c.Lattice()
+		lattice, err := NewPoset(order)
+		if err != nil {
+			t.Fatalf("Lattice of %v is not an order: %v", c.Matrix(), err)
+		}
+		if !lattice.IsLattice() {
+			t.Fatalf("Lattice of %v is not a lattice", c.Matrix())
+		}
+	}
+}
+
+// lecticLess reports whether a comes before b in lectic order: the smallest
+// element in which they differ belongs to b.
+func lecticLess(a, b []int, n int) bool {
+	ma, mb := mask(a, n), mask(b, n)
+	for x := range ma {
+		if ma[x] != mb[x] {
+			return mb[x]
+		}
+	}
+	return false
+}
+
+func TestStemBase(t *testing.T) {
+	rng := rand.New(rand.NewPCG(25, 3))
+	for trial := 0; trial < 200; trial++ {
+		c := randomContext(t, rng)
+		basis := c.StemBase()
+
+		for _, attrs := range subsets(c.Attributes()) {
+			if got, want := follow(attrs, basis), c.closure(attrs); !slices.Equal(got, want) {
+				t.Fatalf("StemBase of %v closes %v to %v, want %v", c.Matrix(), members(attrs), members(got), members(want))
+			}
+		}
+
+		for i, im := range basis {
+			rest := slices.Delete(slices.Clone(basis), i, i+1)
+			premise := mask(im.Premise, c.Attributes())
+			if isSubset(mask(im.Conclusion, c.Attributes()), follow(premise, rest)) {
+				t.Fatalf("%v follows from the rest of the StemBase of %v", im, c.Matrix())
+			}
+		}
+	}
+}
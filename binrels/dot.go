@@ -0,0 +1,101 @@
+package binrels
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type DOTOptions struct {
+	// Names labels the nodes, one per element, as in PrintWithSource.
+	// Elements are labeled by index when Names is empty.
+	Names []string
+	// OmitLoops drops pairs (x, x).
+	OmitLoops bool
+	// Undirected draws every symmetric pair as a single edge without arrows.
+	Undirected bool
+	// Hasse draws the Hasse diagram of an order instead of the relation
+	// itself, with greater elements above smaller ones.
+	Hasse bool
+}
+
+// DOT renders a as a Graphviz digraph. Rows and columns are the same nodes,
+// so a must be square.
+func DOT(a [][]bool, opts ...DOTOptions) (string, error) {
+	var opt DOTOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	n, err := checkSquare("DOT", a)
+	if err != nil {
+		return "", err
+	}
+	if len(opt.Names) != 0 && len(opt.Names) != n {
+		return "", newError("DOT", ErrDimensionMismatch, "%d names for %d elements", len(opt.Names), n)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("digraph R {\n")
+
+	if opt.Hasse {
+		h, err := HasseDiagram(a)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString("\trankdir=BT;\n")
+		sb.WriteString("\tedge [dir=none];\n")
+		writeDOTNodes(&sb, len(a), opt.Names)
+		writeDOTRanks(&sb, h.Levels)
+		for _, p := range h.Covers {
+			fmt.Fprintf(&sb, "\tn%d -> n%d;\n", p.I, p.J)
+		}
+		sb.WriteString("}\n")
+		return sb.String(), nil
+	}
+
+	writeDOTNodes(&sb, len(a), opt.Names)
+	for i := range a {
+		for _, j := range BottomIntersection(a, i) {
+			switch {
+			case i == j:
+				if !opt.OmitLoops {
+					fmt.Fprintf(&sb, "\tn%d -> n%d;\n", i, j)
+				}
+			case opt.Undirected && a[j][i]:
+				if i < j {
+					fmt.Fprintf(&sb, "\tn%d -> n%d [dir=none];\n", i, j)
+				}
+			default:
+				fmt.Fprintf(&sb, "\tn%d -> n%d;\n", i, j)
+			}
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String(), nil
+}
+
+func writeDOTNodes(sb *strings.Builder, n int, names []string) {
+	for i := 0; i < n; i++ {
+		label := strconv.Itoa(i)
+		if len(names) != 0 {
+			label = names[i]
+		}
+		fmt.Fprintf(sb, "\tn%d [label=%s];\n", i, strconv.Quote(label))
+	}
+}
+
+func writeDOTRanks(sb *strings.Builder, levels []int) {
+	byLevel := make(map[int][]string)
+	top := 0
+	for x, level := range levels {
+		byLevel[level] = append(byLevel[level], "n"+strconv.Itoa(x))
+		top = max(top, level)
+	}
+
+	for level := 0; level <= top; level++ {
+		if nodes := byLevel[level]; len(nodes) > 1 {
+			fmt.Fprintf(sb, "\t{ rank=same; %s; }\n", strings.Join(nodes, "; "))
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package binrels
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDOT(t *testing.T) {
+	a := [][]bool{{true, true}, {true, false}}
+	got, err := DOT(a, DOTOptions{Names: []string{"x", "y"}, OmitLoops: true, Undirected: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "digraph R {\n" +
+		"\tn0 [label=\"x\"];\n" +
+		"\tn1 [label=\"y\"];\n" +
+		"\tn0 -> n1 [dir=none];\n" +
+		"}\n"
+	if got != want {
+		t.Errorf("DOT = %q, want %q", got, want)
+	}
+}
+
+func TestDOTRejectsBadShapes(t *testing.T) {
+	if _, err := DOT([][]bool{{false, false, true}, {false, false, false}}); !errors.Is(err, ErrNotSquare) {
+		t.Errorf("DOT of a 2x3 relation: %v, want %v", err, ErrNotSquare)
+	}
+	if _, err := DOT(Zero(2), DOTOptions{Names: []string{"x"}}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("DOT with one name for two elements: %v, want %v", err, ErrDimensionMismatch)
+	}
+	if _, err := DOT([][]bool{{false, true}, {true, false}}, DOTOptions{Hasse: true}); !errors.As(err, new(*CycleError)) {
+		t.Errorf("Hasse DOT of a cycle: %v", err)
+	}
+}
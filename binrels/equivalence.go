@@ -0,0 +1,122 @@
+package binrels
+
+func requireEquivalence(op string, a [][]bool) error {
+	return require(op, a, Reflexive, Symmetric, Transitive)
+}
+
+// EquivalenceClasses returns the classes of an equivalence relation ordered
+// by their smallest element, each class listed in ascending order.
+func EquivalenceClasses(a [][]bool) ([][]int, error) {
+	if err := requireEquivalence("EquivalenceClasses", a); err != nil {
+		return nil, err
+	}
+
+	return classesOf(a), nil
+}
+
+func classesOf(a [][]bool) [][]int {
+	seen := make([]bool, len(a))
+	classes := make([][]int, 0)
+	for x := range a {
+		if seen[x] {
+			continue
+		}
+
+		class := BottomIntersection(a, x)
+		for _, y := range class {
+			seen[y] = true
+		}
+		classes = append(classes, class)
+	}
+	return classes
+}
+
+// Representatives maps every element to the smallest element of its class.
+func Representatives(a [][]bool) ([]int, error) {
+	classes, err := EquivalenceClasses(a)
+	if err != nil {
+		return nil, err
+	}
+
+	rep := make([]int, len(a))
+	for _, class := range classes {
+		for _, x := range class {
+			rep[x] = class[0]
+		}
+	}
+	return rep, nil
+}
+
+// Quotient lifts r onto the classes of the equivalence eq: classes p and q
+// are related when some element of p is related by r to some element of q.
+// Class indices follow EquivalenceClasses(eq), and r must be square with as
+// many elements as eq.
+func Quotient(r, eq [][]bool) ([][]bool, error) {
+	n, err := checkSquare("Quotient", r)
+	if err != nil {
+		return nil, err
+	}
+	if n != len(eq) {
+		return nil, newError("Quotient", ErrDimensionMismatch, "relation has %d elements, equivalence has %d", n, len(eq))
+	}
+
+	classes, err := EquivalenceClasses(eq)
+	if err != nil {
+		return nil, err
+	}
+
+	classOf := make([]int, len(eq))
+	for p, class := range classes {
+		for _, x := range class {
+			classOf[x] = p
+		}
+	}
+
+	result := Zero(len(classes))
+	for x := range r {
+		for _, y := range BottomIntersection(r, x) {
+			result[classOf[x]][classOf[y]] = true
+		}
+	}
+	return result, nil
+}
+
+// FromPartition builds the equivalence relation on n elements whose classes
+// are the given blocks. Every element must appear in exactly one block.
+func FromPartition(n int, blocks [][]int) ([][]bool, error) {
+	if n < 0 {
+		return nil, newError("FromPartition", ErrIndexOutOfRange, "negative size %d", n)
+	}
+
+	seen := make([]bool, n)
+	for _, block := range blocks {
+		if len(block) == 0 {
+			return nil, newError("FromPartition", ErrNotPartition, "empty block")
+		}
+		for _, x := range block {
+			if x < 0 || x >= n {
+				return nil, newError("FromPartition", ErrIndexOutOfRange, "element %d outside [0, %d)", x, n)
+			}
+			if seen[x] {
+				return nil, newError("FromPartition", ErrNotPartition, "element %d appears in more than one block", x)
+			}
+			seen[x] = true
+		}
+	}
+
+	for x := range seen {
+		if !seen[x] {
+			return nil, newError("FromPartition", ErrNotPartition, "element %d is in no block", x)
+		}
+	}
+
+	result := Zero(n)
+	for _, block := range blocks {
+		for _, x := range block {
+			for _, y := range block {
+				result[x][y] = true
+			}
+		}
+	}
+	return result, nil
+}
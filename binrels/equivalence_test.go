@@ -0,0 +1,64 @@
+package binrels
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestFromPartition(t *testing.T) {
+	eq, err := FromPartition(4, [][]int{{0, 2}, {1}, {3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	classes, err := EquivalenceClasses(eq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]int{{0, 2}, {1}, {3}}
+	if !slices.EqualFunc(classes, want, slices.Equal) {
+		t.Errorf("EquivalenceClasses = %v, want %v", classes, want)
+	}
+}
+
+func TestFromPartitionErrors(t *testing.T) {
+	tests := []struct {
+		n      int
+		blocks [][]int
+		kind   error
+	}{
+		{-1, nil, ErrIndexOutOfRange},
+		{2, [][]int{{0, 2}}, ErrIndexOutOfRange},
+		{2, [][]int{{-1}, {0, 1}}, ErrIndexOutOfRange},
+		{2, [][]int{{0}, {}, {1}}, ErrNotPartition},
+		{2, [][]int{{0, 1}, {1}}, ErrNotPartition},
+		{3, [][]int{{0, 1}}, ErrNotPartition},
+	}
+	for _, tt := range tests {
+		if _, err := FromPartition(tt.n, tt.blocks); !errors.Is(err, tt.kind) {
+			t.Errorf("FromPartition(%d, %v) = %v, want %v", tt.n, tt.blocks, err, tt.kind)
+		}
+	}
+}
+
+func TestQuotient(t *testing.T) {
+	eq, _ := FromPartition(4, [][]int{{0, 1}, {2, 3}})
+	r := Zero(4)
+	r[1][2] = true
+
+	got, err := Quotient(r, eq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := [][]bool{{false, true}, {false, false}}; !Equal(got, want) {
+		t.Errorf("Quotient = %v, want %v", got, want)
+	}
+
+	if _, err := Quotient(ZeroRect(4, 5), eq); !errors.Is(err, ErrNotSquare) {
+		t.Errorf("Quotient of a 4x5 relation: %v", err)
+	}
+	if _, err := Quotient(Zero(3), eq); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Quotient of a 3x3 relation by a 4x4 equivalence: %v", err)
+	}
+}
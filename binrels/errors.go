@@ -0,0 +1,93 @@
+package binrels
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrDimensionMismatch = errors.New("dimension mismatch")
+	ErrNotSquare         = errors.New("relation is not square")
+	ErrRaggedRows        = errors.New("ragged rows")
+	ErrIndexOutOfRange   = errors.New("index out of range")
+	ErrNegativePower     = errors.New("negative power")
+	ErrGradeOutOfRange   = errors.New("membership grade outside [0, 1]")
+	ErrNotPartition      = errors.New("blocks do not form a partition")
+
+	ErrNotFunctional        = errors.New("relation is not a function")
+	ErrNotDefinedEverywhere = errors.New("relation is not defined everywhere")
+	ErrNotInjective         = errors.New("relation is not injective")
+	ErrNotSurjective        = errors.New("relation is not surjective")
+)
+
+// Error describes invalid input rejected by one of the checked functions.
+// Kind is one of the Err* values above and can be matched with errors.Is.
+type Error struct {
+	Op     string
+	Kind   error
+	Detail string
+}
+
+func (e *Error) Error() string {
+	if e.Detail == "" {
+		return fmt.Sprintf("%s: %v", e.Op, e.Kind)
+	}
+	return fmt.Sprintf("%s: %v: %s", e.Op, e.Kind, e.Detail)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
+
+func newError(op string, kind error, format string, args ...any) *Error {
+	return &Error{Op: op, Kind: kind, Detail: fmt.Sprintf(format, args...)}
+}
+
+// Shape returns the number of rows and columns of a, or an error when its
+// rows differ in length.
+func Shape(a [][]bool) (rows, cols int, err error) {
+	if len(a) == 0 {
+		return 0, 0, nil
+	}
+
+	for i := range a {
+		if len(a[i]) != len(a[0]) {
+			return 0, 0, newError("Shape", ErrRaggedRows, "row %d has %d columns, row 0 has %d", i, len(a[i]), len(a[0]))
+		}
+	}
+	return len(a), len(a[0]), nil
+}
+
+func checkShape(op string, a [][]bool) (rows, cols int, err error) {
+	rows, cols, err = Shape(a)
+	if err != nil {
+		err.(*Error).Op = op
+	}
+	return rows, cols, err
+}
+
+func checkSquare(op string, a [][]bool) (int, error) {
+	rows, cols, err := checkShape(op, a)
+	if err != nil {
+		return 0, err
+	}
+	if rows != cols {
+		return 0, newError(op, ErrNotSquare, "%dx%d", rows, cols)
+	}
+	return rows, nil
+}
+
+func checkSameShape(op string, a, b [][]bool) error {
+	rowsA, colsA, err := checkShape(op, a)
+	if err != nil {
+		return err
+	}
+	rowsB, colsB, err := checkShape(op, b)
+	if err != nil {
+		return err
+	}
+	if rowsA != rowsB || colsA != colsB {
+		return newError(op, ErrDimensionMismatch, "%dx%d and %dx%d", rowsA, colsA, rowsB, colsB)
+	}
+	return nil
+}
@@ -0,0 +1,499 @@
+package binrels
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Relation algebra expressions, loosest binding first:
+//
+//	R ∪ S, R | S      union          R \ S, R - S   difference
+//	R ∩ S, R & S      intersection
+//	R ∘ S, R ; S      composition
+//	~R, ¬R            complement
+//	Rᵀ, R', R^T, R^-1 transpose      R^n            n-th power
+//	R⁺, R^+           transitive closure
+//	R*, R^*           reflexive transitive closure
+//
+// Names are looked up in the bindings passed to Eval. I, O and U stand for
+// the identity, empty and universal relations unless they are bound; they
+// take their size from the bindings, which must then all be n×n. The calls
+// refl(R), sym(R), tc(R), rtc(R) and eq(R) apply the matching closures.
+
+type ParseError struct {
+	Pos int
+	Msg string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("position %d: %s", e.Pos, e.Msg)
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+const operatorRunes = "∪|∩&\\-∘;~¬'ᵀ^⁺*()+"
+
+func lex(src string) ([]token, error) {
+	runes := []rune(src)
+	toks := make([]token, 0)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case strings.ContainsRune(operatorRunes, r):
+			toks = append(toks, token{tokOp, string(r), i})
+			i++
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') && !strings.ContainsRune(operatorRunes, runes[i]) {
+				i++
+			}
+			toks = append(toks, token{tokIdent, string(runes[start:i]), start})
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && unicode.IsDigit(runes[i]) {
+				i++
+			}
+			toks = append(toks, token{tokNumber, string(runes[start:i]), start})
+		default:
+			return nil, &ParseError{Pos: i, Msg: fmt.Sprintf("unexpected character %q", r)}
+		}
+	}
+	return append(toks, token{tokEOF, "", len(runes)}), nil
+}
+
+type node interface {
+	eval(ev *evaluator) ([][]bool, error)
+	String() string
+}
+
+type nameNode struct {
+	name string
+	pos  int
+}
+
+type unaryNode struct {
+	op  string
+	x   node
+	pos int
+}
+
+type binaryNode struct {
+	op   string
+	x, y node
+	pos  int
+}
+
+type powerNode struct {
+	x   node
+	n   int
+	pos int
+}
+
+type callNode struct {
+	fn  string
+	x   node
+	pos int
+}
+
+func (n *nameNode) String() string {
+	return n.name
+}
+
+func (n *unaryNode) String() string {
+	return unaryString(n.op, n.x)
+}
+
+func (n *binaryNode) String() string {
+	return "(" + n.x.String() + " " + n.op + " " + n.y.String() + ")"
+}
+
+func (n *powerNode) String() string {
+	return postfixOperand(n.x) + "^" + strconv.Itoa(n.n)
+}
+
+func (n *callNode) String() string {
+	return n.fn + "(" + n.x.String() + ")"
+}
+
+func unaryString(op string, x node) string {
+	if op == "~" {
+		return "~" + x.String()
+	}
+	return postfixOperand(x) + op
+}
+
+// postfixOperand parenthesizes a complement so that a postfix operator
+// applied to it does not bind to its operand instead.
+func postfixOperand(x node) string {
+	if u, ok := x.(*unaryNode); ok && u.op == "~" {
+		return "(" + x.String() + ")"
+	}
+	return x.String()
+}
+
+type parser struct {
+	toks []token
+	i    int
+}
+
+func (p *parser) peek() token {
+	return p.toks[p.i]
+}
+
+func (p *parser) next() token {
+	t := p.toks[p.i]
+	if t.kind != tokEOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *parser) errorf(t token, format string, args ...any) error {
+	return &ParseError{Pos: t.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func describe(t token) string {
+	if t.kind == tokEOF {
+		return "end of expression"
+	}
+	return strconv.Quote(t.text)
+}
+
+func (p *parser) expect(text string) error {
+	if t := p.next(); t.kind != tokOp || t.text != text {
+		return p.errorf(t, "expected %q, got %s", text, describe(t))
+	}
+	return nil
+}
+
+func (p *parser) binary(ops map[string]string, operand func() (node, error)) (node, error) {
+	x, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		t := p.peek()
+		op, ok := ops[t.text]
+		if t.kind != tokOp || !ok {
+			return x, nil
+		}
+		p.next()
+
+		y, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		x = &binaryNode{op: op, x: x, y: y, pos: t.pos}
+	}
+}
+
+var (
+	unionOps        = map[string]string{"∪": "∪", "|": "∪", "\\": "\\", "-": "\\"}
+	intersectionOps = map[string]string{"∩": "∩", "&": "∩"}
+	compositionOps  = map[string]string{"∘": "∘", ";": "∘"}
+)
+
+func (p *parser) parseUnion() (node, error) {
+	return p.binary(unionOps, p.parseIntersection)
+}
+
+func (p *parser) parseIntersection() (node, error) {
+	return p.binary(intersectionOps, p.parseComposition)
+}
+
+func (p *parser) parseComposition() (node, error) {
+	return p.binary(compositionOps, p.parseUnary)
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if t := p.peek(); t.kind == tokOp && (t.text == "~" || t.text == "¬") {
+		p.next()
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{op: "~", x: x, pos: t.pos}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *parser) parsePostfix() (node, error) {
+	x, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		t := p.peek()
+		if t.kind != tokOp {
+			return x, nil
+		}
+
+		switch t.text {
+		case "'", "ᵀ":
+			p.next()
+			x = &unaryNode{op: "ᵀ", x: x, pos: t.pos}
+		case "⁺":
+			p.next()
+			x = &unaryNode{op: "⁺", x: x, pos: t.pos}
+		case "*":
+			p.next()
+			x = &unaryNode{op: "*", x: x, pos: t.pos}
+		case "^":
+			p.next()
+			if x, err = p.parseExponent(x, t); err != nil {
+				return nil, err
+			}
+		default:
+			return x, nil
+		}
+	}
+}
+
+func (p *parser) parseExponent(x node, caret token) (node, error) {
+	t := p.next()
+	switch {
+	case t.kind == tokIdent && t.text == "T":
+		return &unaryNode{op: "ᵀ", x: x, pos: caret.pos}, nil
+	case t.kind == tokOp && t.text == "+":
+		return &unaryNode{op: "⁺", x: x, pos: caret.pos}, nil
+	case t.kind == tokOp && t.text == "*":
+		return &unaryNode{op: "*", x: x, pos: caret.pos}, nil
+	case t.kind == tokOp && t.text == "-":
+		if one := p.next(); one.kind != tokNumber || one.text != "1" {
+			return nil, p.errorf(one, "only ^-1 is allowed as a negative exponent")
+		}
+		return &unaryNode{op: "ᵀ", x: x, pos: caret.pos}, nil
+	case t.kind == tokNumber:
+		n, err := strconv.Atoi(t.text)
+		if err != nil {
+			return nil, p.errorf(t, "exponent %s is too large", t.text)
+		}
+		return &powerNode{x: x, n: n, pos: caret.pos}, nil
+	}
+	return nil, p.errorf(t, "expected exponent after \"^\", got %s", describe(t))
+}
+
+var closureCalls = map[string]func(a [][]bool) [][]bool{
+	"refl": ReflexiveClosure,
+	"sym":  SymmetricClosure,
+	"tc":   TransitiveClosure,
+	"rtc":  Reachability,
+	"eq":   EquivalenceClosure,
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch {
+	case t.kind == tokIdent:
+		if next := p.peek(); next.kind == tokOp && next.text == "(" {
+			if _, ok := closureCalls[t.text]; !ok {
+				return nil, p.errorf(t, "unknown function %q", t.text)
+			}
+			p.next()
+			x, err := p.parseUnion()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return &callNode{fn: t.text, x: x, pos: t.pos}, nil
+		}
+		return &nameNode{name: t.text, pos: t.pos}, nil
+	case t.kind == tokOp && t.text == "(":
+		x, err := p.parseUnion()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return x, nil
+	}
+	return nil, p.errorf(t, "expected relation, got %s", describe(t))
+}
+
+// Expr is a parsed relation algebra expression that can be evaluated
+// against different bindings.
+type Expr struct {
+	root node
+}
+
+func ParseExpr(src string) (*Expr, error) {
+	toks, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{toks: toks}
+	root, err := p.parseUnion()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, p.errorf(t, "unexpected %s", describe(t))
+	}
+	return &Expr{root: root}, nil
+}
+
+// String returns the expression fully parenthesized.
+func (e *Expr) String() string {
+	return e.root.String()
+}
+
+func (e *Expr) Eval(env map[string][][]bool) ([][]bool, error) {
+	return e.root.eval(&evaluator{env: env})
+}
+
+// Eval parses src and evaluates it against env.
+func Eval(src string, env map[string][][]bool) ([][]bool, error) {
+	e, err := ParseExpr(src)
+	if err != nil {
+		return nil, err
+	}
+	return e.Eval(env)
+}
+
+type evaluator struct {
+	env map[string][][]bool
+}
+
+// universe returns the common size of the bound relations, which the
+// constants I, O and U adopt.
+func (ev *evaluator) universe(pos int) (int, error) {
+	n := -1
+	for name, a := range ev.env {
+		size, err := checkSquare(name, a)
+		if err != nil {
+			return 0, fmt.Errorf("position %d: constant needs square bindings: %w", pos, err)
+		}
+		if n != -1 && size != n {
+			return 0, fmt.Errorf("position %d: constant needs bindings of one size, got %d and %d", pos, n, size)
+		}
+		n = size
+	}
+
+	if n == -1 {
+		return 0, fmt.Errorf("position %d: constant needs at least one binding to take its size from", pos)
+	}
+	return n, nil
+}
+
+func (n *nameNode) eval(ev *evaluator) ([][]bool, error) {
+	if a, ok := ev.env[n.name]; ok {
+		x, err := CopyChecked(a)
+		if err != nil {
+			return nil, fmt.Errorf("position %d: relation %q: %w", n.pos, n.name, err)
+		}
+		return x, nil
+	}
+
+	constant, ok := map[string]func(n int) [][]bool{
+		"I": Identity,
+		"O": Zero,
+		"U": func(n int) [][]bool { return Complement(Zero(n)) },
+	}[n.name]
+	if !ok {
+		return nil, fmt.Errorf("position %d: unknown relation %q", n.pos, n.name)
+	}
+
+	size, err := ev.universe(n.pos)
+	if err != nil {
+		return nil, err
+	}
+	return constant(size), nil
+}
+
+func (n *unaryNode) eval(ev *evaluator) ([][]bool, error) {
+	x, err := n.x.eval(ev)
+	if err != nil {
+		return nil, err
+	}
+
+	var result [][]bool
+	switch n.op {
+	case "~":
+		result, err = ComplementChecked(x)
+	case "ᵀ":
+		result, err = TransposeChecked(x)
+	case "⁺":
+		result, err = TransitiveClosureChecked(x)
+	case "*":
+		result, err = ReachabilityChecked(x)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("position %d: %w", n.pos, err)
+	}
+	return result, nil
+}
+
+func (n *binaryNode) eval(ev *evaluator) ([][]bool, error) {
+	x, err := n.x.eval(ev)
+	if err != nil {
+		return nil, err
+	}
+	y, err := n.y.eval(ev)
+	if err != nil {
+		return nil, err
+	}
+
+	var result [][]bool
+	switch n.op {
+	case "∪":
+		result, err = UnionChecked(x, y)
+	case "\\":
+		result, err = DiffChecked(x, y)
+	case "∩":
+		result, err = IntersectionChecked(x, y)
+	case "∘":
+		result, err = CompositionChecked(x, y)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("position %d: %w", n.pos, err)
+	}
+	return result, nil
+}
+
+func (n *powerNode) eval(ev *evaluator) ([][]bool, error) {
+	x, err := n.x.eval(ev)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := PowerChecked(x, n.n)
+	if err != nil {
+		return nil, fmt.Errorf("position %d: %w", n.pos, err)
+	}
+	return result, nil
+}
+
+func (n *callNode) eval(ev *evaluator) ([][]bool, error) {
+	x, err := n.x.eval(ev)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := checkSquare(n.fn, x); err != nil {
+		return nil, fmt.Errorf("position %d: %w", n.pos, err)
+	}
+	return closureCalls[n.fn](x), nil
+}
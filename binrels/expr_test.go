@@ -0,0 +1,168 @@
+package binrels
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseExprString(t *testing.T) {
+	tests := []struct {
+		src, want string
+	}{
+		// precedence and associativity
+		{"R ∪ S ∩ T", "(R ∪ (S ∩ T))"},
+		{"R ∩ S ∪ T", "((R ∩ S) ∪ T)"},
+		{"R ∩ S ∘ T", "(R ∩ (S ∘ T))"},
+		{"R ∘ S ∘ T", "((R ∘ S) ∘ T)"},
+		{"R \\ S ∪ T", "((R \\ S) ∪ T)"},
+		{"R ∪ (S ∪ T)", "(R ∪ (S ∪ T))"},
+		{"~R ∘ S", "(~R ∘ S)"},
+		{"~Rᵀ", "~Rᵀ"},
+		{"(~R)ᵀ", "(~R)ᵀ"},
+		{"~~R", "~~R"},
+		{"R^2ᵀ", "R^2ᵀ"},
+		{"Rᵀ^2", "Rᵀ^2"},
+		{"tc(R ∪ S)", "tc((R ∪ S))"},
+
+		// every operator spelling
+		{"R | S", "(R ∪ S)"},
+		{"R - S", "(R \\ S)"},
+		{"R & S", "(R ∩ S)"},
+		{"R ; S", "(R ∘ S)"},
+		{"¬R", "~R"},
+		{"R'", "Rᵀ"},
+		{"R^T", "Rᵀ"},
+		{"R^-1", "Rᵀ"},
+		{"R^+", "R⁺"},
+		{"R⁺", "R⁺"},
+		{"R^*", "R*"},
+		{"R*", "R*"},
+		{"R^10", "R^10"},
+		{"refl(R)", "refl(R)"},
+		{"sym(R)", "sym(R)"},
+		{"rtc(R)", "rtc(R)"},
+		{"eq(R)", "eq(R)"},
+	}
+
+	for _, tt := range tests {
+		e, err := ParseExpr(tt.src)
+		if err != nil {
+			t.Errorf("ParseExpr(%q): %v", tt.src, err)
+			continue
+		}
+		if got := e.String(); got != tt.want {
+			t.Errorf("ParseExpr(%q).String() = %q, want %q", tt.src, got, tt.want)
+		}
+
+		again, err := ParseExpr(e.String())
+		if err != nil {
+			t.Errorf("ParseExpr(%q) of the printed form: %v", e.String(), err)
+			continue
+		}
+		if got := again.String(); got != tt.want {
+			t.Errorf("round trip of %q gives %q", tt.want, got)
+		}
+	}
+}
+
+func TestParseExprErrors(t *testing.T) {
+	tests := []struct {
+		src string
+		pos int
+	}{
+		{"", 0},
+		{"R ∪", 3},
+		{"R $ S", 2},
+		{"R S", 2},
+		{"(R ∪ S", 6},
+		{"R)", 1},
+		{"foo(R)", 0},
+		{"tc(R", 4},
+		{"R^", 2},
+		{"R^x", 2},
+		{"R^-2", 3},
+		{"R^99999999999999999999", 2},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseExpr(tt.src)
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Errorf("ParseExpr(%q) = %v, want a ParseError", tt.src, err)
+			continue
+		}
+		if pe.Pos != tt.pos {
+			t.Errorf("ParseExpr(%q) failed at %d, want %d: %v", tt.src, pe.Pos, tt.pos, err)
+		}
+	}
+}
+
+func TestEval(t *testing.T) {
+	env := map[string][][]bool{
+		"R": {{false, true, false}, {false, false, true}, {false, false, false}},
+		"S": {{true, false, false}, {false, false, false}, {false, true, false}},
+	}
+
+	tests := []struct {
+		src  string
+		want [][]bool
+	}{
+		{"R ∪ S", Union(env["R"], env["S"])},
+		{"R ∩ ~S", Diff(env["R"], env["S"])},
+		{"R ∘ R", Composition(env["R"], env["R"])},
+		{"R^2", Power(env["R"], 2)},
+		{"R^0", Identity(3)},
+		{"R⁺", TransitiveClosure(env["R"])},
+		{"R*", Reachability(env["R"])},
+		{"R* \\ R⁺", Identity(3)},
+		{"I ∪ R", ReflexiveClosure(env["R"])},
+		{"U - ~R", env["R"]},
+		{"O ∘ R", Zero(3)},
+		{"Sᵀ", Transpose(env["S"])},
+		{"eq(R)", EquivalenceClosure(env["R"])},
+	}
+
+	for _, tt := range tests {
+		got, err := Eval(tt.src, env)
+		if err != nil {
+			t.Errorf("Eval(%q): %v", tt.src, err)
+			continue
+		}
+		if !Equal(got, tt.want) {
+			t.Errorf("Eval(%q) = %v, want %v", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	square := Zero(2)
+	tests := []struct {
+		src  string
+		env  map[string][][]bool
+		kind error
+		pos  string
+	}{
+		{"R", map[string][][]bool{"R": {{true, false}, {true}}}, ErrRaggedRows, "position 0"},
+		{"R ∪ S", map[string][][]bool{"R": square, "S": {{true}, {true, false}}}, ErrRaggedRows, "position 4"},
+		{"R ∪ S", map[string][][]bool{"R": square, "S": Zero(3)}, ErrDimensionMismatch, "position 2"},
+		{"tc(R)", map[string][][]bool{"R": ZeroRect(2, 3)}, ErrNotSquare, "position 0"},
+		{"R^2", map[string][][]bool{"R": ZeroRect(2, 3)}, ErrNotSquare, "position 1"},
+		{"R ∘ R", map[string][][]bool{"R": ZeroRect(2, 3)}, ErrDimensionMismatch, "position 2"},
+	}
+
+	for _, tt := range tests {
+		_, err := Eval(tt.src, tt.env)
+		if !errors.Is(err, tt.kind) {
+			t.Errorf("Eval(%q) = %v, want %v", tt.src, err, tt.kind)
+			continue
+		}
+		if got := err.Error(); !strings.HasPrefix(got, tt.pos) {
+			t.Errorf("Eval(%q) = %q, want it at %s", tt.src, got, tt.pos)
+		}
+	}
+
+	if _, err := Eval("X", map[string][][]bool{"R": square}); err == nil {
+		t.Errorf("Eval of an unknown name succeeded")
+	}
+}
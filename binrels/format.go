@@ -0,0 +1,337 @@
+package binrels
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type Format int
+
+const (
+	PlainFormat Format = iota
+	MarkdownFormat
+	LaTeXTabularFormat
+	LaTeXMatrixFormat
+	CSVFormat
+	HTMLFormat
+)
+
+type FormatOptions struct {
+	Format Format
+	// True and False are the cell symbols, "1" and "0" by default.
+	True  string
+	False string
+	// Labels names the rows, and the columns too unless ColLabels is set.
+	// PlainFormat without labels keeps the layout of Print, with labels the
+	// layout of PrintWithSource.
+	Labels    []string
+	ColLabels []string
+}
+
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) printf(format string, args ...any) {
+	if ew.err != nil {
+		return
+	}
+	_, ew.err = fmt.Fprintf(ew.w, format, args...)
+}
+
+type table struct {
+	matrix    [][]bool
+	rowLabels []string
+	colLabels []string
+	yes, no   string
+}
+
+func (t *table) labeled() bool {
+	return t.rowLabels != nil
+}
+
+func (t *table) cell(i, j int) string {
+	if t.matrix[i][j] {
+		return t.yes
+	}
+	return t.no
+}
+
+func (t *table) cols() int {
+	if len(t.matrix) == 0 {
+		return len(t.colLabels)
+	}
+	return len(t.matrix[0])
+}
+
+func newTable(a [][]bool, opt FormatOptions) (*table, error) {
+	t := &table{matrix: a, yes: opt.True, no: opt.False}
+	if t.yes == "" {
+		t.yes = "1"
+	}
+	if t.no == "" {
+		t.no = "0"
+	}
+
+	if opt.Labels == nil && opt.ColLabels == nil {
+		return t, nil
+	}
+
+	rows, cols, err := checkShape("Fprint", a)
+	if err != nil {
+		return nil, err
+	}
+
+	t.rowLabels = opt.Labels
+	t.colLabels = opt.ColLabels
+	if t.colLabels == nil {
+		t.colLabels = opt.Labels
+	}
+	if t.rowLabels == nil {
+		t.rowLabels = indexLabels(rows)
+	}
+
+	if len(t.rowLabels) != rows || rows != 0 && len(t.colLabels) != cols {
+		return nil, newError("Fprint", ErrDimensionMismatch, "%d row and %d column labels for a %dx%d relation", len(t.rowLabels), len(t.colLabels), rows, cols)
+	}
+	return t, nil
+}
+
+func indexLabels(n int) []string {
+	labels := make([]string, n)
+	for i := range labels {
+		labels[i] = strconv.Itoa(i)
+	}
+	return labels
+}
+
+// Fprint writes a to w in the format chosen by opts, plain text by default.
+func Fprint(w io.Writer, a [][]bool, opts ...FormatOptions) error {
+	var opt FormatOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	t, err := newTable(a, opt)
+	if err != nil {
+		return err
+	}
+
+	switch opt.Format {
+	case PlainFormat:
+		return t.plain(w)
+	case MarkdownFormat:
+		return t.markdown(w)
+	case LaTeXTabularFormat:
+		return t.tabular(w)
+	case LaTeXMatrixFormat:
+		return t.pmatrix(w)
+	case CSVFormat:
+		return t.csv(w)
+	case HTMLFormat:
+		return t.html(w)
+	}
+	return fmt.Errorf("unknown format %d", opt.Format)
+}
+
+func (t *table) plain(w io.Writer) error {
+	ew := &errWriter{w: w}
+
+	if !t.labeled() {
+		for i := range t.matrix {
+			ew.printf("\n")
+			if i != 0 {
+				ew.printf("%s\n", strings.Repeat("----", len(t.matrix[i])))
+			}
+			for j := range t.matrix[i] {
+				ew.printf(" %s ", t.cell(i, j))
+				if j < len(t.matrix[i])-1 {
+					ew.printf("|")
+				}
+			}
+		}
+		ew.printf("\n")
+		return ew.err
+	}
+
+	width := max(len(t.yes), len(t.no))
+	for _, s := range t.rowLabels {
+		width = max(width, len(s))
+	}
+	for _, s := range t.colLabels {
+		width = max(width, len(s))
+	}
+
+	ew.printf("%-*s | ", width, "")
+	for j, s := range t.colLabels {
+		ew.printf("%-*s", width, s)
+		if j < len(t.colLabels)-1 {
+			ew.printf("| ")
+		}
+	}
+	for i := range t.matrix {
+		ew.printf("\n")
+		ew.printf("--------------------\n")
+		ew.printf(" %-*s| ", width, t.rowLabels[i])
+		for j := range t.matrix[i] {
+			ew.printf("%-*s", width, t.cell(i, j))
+			if j < len(t.matrix[i])-1 {
+				ew.printf("| ")
+			}
+		}
+	}
+	ew.printf("\n")
+	return ew.err
+}
+
+func (t *table) markdown(w io.Writer) error {
+	ew := &errWriter{w: w}
+	escape := func(s string) string {
+		return strings.ReplaceAll(s, "|", `\|`)
+	}
+
+	header := t.colLabels
+	if header == nil {
+		header = indexLabels(t.cols())
+	}
+
+	if t.labeled() {
+		ew.printf("| ")
+	}
+	for _, s := range header {
+		ew.printf("| %s ", escape(s))
+	}
+	ew.printf("|\n")
+
+	if t.labeled() {
+		ew.printf("|---")
+	}
+	ew.printf("%s|\n", strings.Repeat("|:-:", len(header)))
+
+	for i := range t.matrix {
+		if t.labeled() {
+			ew.printf("| %s ", escape(t.rowLabels[i]))
+		}
+		for j := range t.matrix[i] {
+			ew.printf("| %s ", escape(t.cell(i, j)))
+		}
+		ew.printf("|\n")
+	}
+	return ew.err
+}
+
+var latexEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`&`, `\&`,
+	`%`, `\%`,
+	`$`, `\$`,
+	`#`, `\#`,
+	`_`, `\_`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`,
+)
+
+func (t *table) latexRows(ew *errWriter, labeled bool) {
+	for i := range t.matrix {
+		cells := make([]string, 0, len(t.matrix[i])+1)
+		if labeled {
+			cells = append(cells, latexEscaper.Replace(t.rowLabels[i]))
+		}
+		for j := range t.matrix[i] {
+			cells = append(cells, latexEscaper.Replace(t.cell(i, j)))
+		}
+		ew.printf("\t%s \\\\\n", strings.Join(cells, " & "))
+	}
+}
+
+func (t *table) tabular(w io.Writer) error {
+	ew := &errWriter{w: w}
+
+	spec := strings.Repeat("c", t.cols())
+	if t.labeled() {
+		spec = "c|" + spec
+	}
+	ew.printf("\\begin{tabular}{%s}\n", spec)
+
+	if t.labeled() {
+		header := []string{""}
+		for _, s := range t.colLabels {
+			header = append(header, latexEscaper.Replace(s))
+		}
+		ew.printf("\t%s \\\\\n", strings.Join(header, " & "))
+		ew.printf("\t\\hline\n")
+	}
+
+	t.latexRows(ew, t.labeled())
+	ew.printf("\\end{tabular}\n")
+	return ew.err
+}
+
+// pmatrix ignores labels, which a bare matrix has no place for.
+func (t *table) pmatrix(w io.Writer) error {
+	ew := &errWriter{w: w}
+	ew.printf("\\begin{pmatrix}\n")
+	t.latexRows(ew, false)
+	ew.printf("\\end{pmatrix}\n")
+	return ew.err
+}
+
+func (t *table) csv(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	if t.labeled() {
+		if err := cw.Write(append([]string{""}, t.colLabels...)); err != nil {
+			return err
+		}
+	}
+
+	for i := range t.matrix {
+		record := make([]string, 0, len(t.matrix[i])+1)
+		if t.labeled() {
+			record = append(record, t.rowLabels[i])
+		}
+		for j := range t.matrix[i] {
+			record = append(record, t.cell(i, j))
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func (t *table) html(w io.Writer) error {
+	ew := &errWriter{w: w}
+	ew.printf("<table>\n")
+
+	if t.labeled() {
+		ew.printf("\t<thead>\n\t\t<tr><th></th>")
+		for _, s := range t.colLabels {
+			ew.printf("<th>%s</th>", html.EscapeString(s))
+		}
+		ew.printf("</tr>\n\t</thead>\n")
+	}
+
+	ew.printf("\t<tbody>\n")
+	for i := range t.matrix {
+		ew.printf("\t\t<tr>")
+		if t.labeled() {
+			ew.printf("<th>%s</th>", html.EscapeString(t.rowLabels[i]))
+		}
+		for j := range t.matrix[i] {
+			ew.printf("<td>%s</td>", html.EscapeString(t.cell(i, j)))
+		}
+		ew.printf("</tr>\n")
+	}
+	ew.printf("\t</tbody>\n</table>\n")
+	return ew.err
+}
@@ -0,0 +1,56 @@
+package binrels
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFprintPlain(t *testing.T) {
+	a := [][]bool{{true, false, true}, {false, true, false}, {true, true, false}}
+	tests := []struct {
+		name string
+		opt  FormatOptions
+		want string
+	}{
+		{
+			"unlabeled",
+			FormatOptions{},
+			"\n 1 | 0 | 1 " +
+				"\n------------\n 0 | 1 | 0 " +
+				"\n------------\n 1 | 1 | 0 \n",
+		},
+		{
+			"labeled",
+			FormatOptions{Labels: []string{"x", "y", "z"}},
+			"  | x| y| z" +
+				"\n--------------------\n x| 1| 0| 1" +
+				"\n--------------------\n y| 0| 1| 0" +
+				"\n--------------------\n z| 1| 1| 0\n",
+		},
+		{
+			"labeled with long symbols",
+			FormatOptions{Labels: []string{"x", "y", "z"}, True: "yes", False: "no"},
+			"    | x  | y  | z  " +
+				"\n--------------------\n x  | yes| no | yes" +
+				"\n--------------------\n y  | no | yes| no " +
+				"\n--------------------\n z  | yes| yes| no \n",
+		},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := Fprint(&buf, a, tt.opt); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got := buf.String(); got != tt.want {
+			t.Errorf("%s: got\n%q\nwant\n%q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFprintLabelMismatch(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Fprint(&buf, Zero(3), FormatOptions{Labels: []string{"x"}}); err == nil {
+		t.Errorf("Fprint with one label for three rows succeeded")
+	}
+}
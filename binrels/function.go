@@ -0,0 +1,135 @@
+package binrels
+
+import "slices"
+
+// The function checks read a as a relation from its rows to its columns, so
+// a may be rectangular.
+
+func checkFunctional(op string, a [][]bool) error {
+	for x := range a {
+		if images := BottomIntersection(a, x); len(images) > 1 {
+			return newError(op, ErrNotFunctional, "%d is mapped to both %d and %d", x, images[0], images[1])
+		}
+	}
+	return nil
+}
+
+func checkDefinedEverywhere(op string, a [][]bool) error {
+	if len(DefinitionDomain(a)) == len(a) {
+		return nil
+	}
+
+	for x := range a {
+		if !slices.Contains(a[x], true) {
+			return newError(op, ErrNotDefinedEverywhere, "%d has no image", x)
+		}
+	}
+	return nil
+}
+
+func checkInjective(op string, a [][]bool) error {
+	if len(a) == 0 {
+		return nil
+	}
+
+	for y := range a[0] {
+		if preimages := TopIntersection(a, y); len(preimages) > 1 {
+			return newError(op, ErrNotInjective, "%d and %d are both mapped to %d", preimages[0], preimages[1], y)
+		}
+	}
+	return nil
+}
+
+func checkSurjective(op string, a [][]bool, onto []int) error {
+	image := make(map[int]bool)
+	for _, y := range MeaningDomain(a) {
+		image[y] = true
+	}
+	for _, y := range onto {
+		if !image[y] {
+			return newError(op, ErrNotSurjective, "nothing is mapped to %d", y)
+		}
+	}
+	return nil
+}
+
+func codomain(a [][]bool) []int {
+	if len(a) == 0 {
+		return nil
+	}
+
+	res := make([]int, len(a[0]))
+	for y := range res {
+		res[y] = y
+	}
+	return res
+}
+
+// IsPartialFunction reports whether every element has at most one image.
+func IsPartialFunction(a [][]bool) bool {
+	return checkFunctional("IsPartialFunction", a) == nil
+}
+
+// IsFunction reports whether every element has exactly one image.
+func IsFunction(a [][]bool) bool {
+	return checkFunctional("IsFunction", a) == nil && checkDefinedEverywhere("IsFunction", a) == nil
+}
+
+// IsInjective reports whether no two elements share an image.
+func IsInjective(a [][]bool) bool {
+	return checkInjective("IsInjective", a) == nil
+}
+
+// IsSurjective reports whether every element of onto is an image. The whole
+// codomain is used when onto is omitted.
+func IsSurjective(a [][]bool, onto ...[]int) bool {
+	if len(onto) > 0 {
+		return checkSurjective("IsSurjective", a, onto[0]) == nil
+	}
+	return checkSurjective("IsSurjective", a, codomain(a)) == nil
+}
+
+func IsBijective(a [][]bool) bool {
+	return IsFunction(a) && IsInjective(a) && IsSurjective(a)
+}
+
+// AsFunction returns the image of every element of a partial function, with
+// -1 for elements that have none.
+func AsFunction(a [][]bool) ([]int, error) {
+	if err := checkFunctional("AsFunction", a); err != nil {
+		return nil, err
+	}
+
+	f := make([]int, len(a))
+	for x := range a {
+		f[x] = slices.Index(a[x], true)
+	}
+	return f, nil
+}
+
+// Inverse returns the inverse of an injective partial function, which is
+// again an injective partial function.
+func Inverse(a [][]bool) ([][]bool, error) {
+	if err := checkFunctional("Inverse", a); err != nil {
+		return nil, err
+	}
+	if err := checkInjective("Inverse", a); err != nil {
+		return nil, err
+	}
+	return Transpose(a), nil
+}
+
+// Permutation returns the permutation p encoded by a bijection of a set onto
+// itself, where p[x] is the image of x.
+func Permutation(a [][]bool) ([]int, error) {
+	if _, err := checkSquare("Permutation", a); err != nil {
+		return nil, err
+	}
+
+	for _, check := range []func(string, [][]bool) error{checkFunctional, checkDefinedEverywhere, checkInjective} {
+		if err := check("Permutation", a); err != nil {
+			return nil, err
+		}
+	}
+	return AsFunction(a)
+}
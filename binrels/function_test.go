@@ -0,0 +1,60 @@
+package binrels
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestFunctionChecks(t *testing.T) {
+	// 0 ↦ 1, 1 ↦ 2, 2 ↦ 0
+	cycle := [][]bool{{false, true, false}, {false, false, true}, {true, false, false}}
+	if !IsFunction(cycle) || !IsBijective(cycle) {
+		t.Errorf("a cyclic permutation is not reported as a bijection")
+	}
+	if p, err := Permutation(cycle); err != nil || !slices.Equal(p, []int{1, 2, 0}) {
+		t.Errorf("Permutation = %v, %v", p, err)
+	}
+	if inv, err := Inverse(cycle); err != nil || !Equal(inv, Transpose(cycle)) {
+		t.Errorf("Inverse = %v, %v", inv, err)
+	}
+
+	// 0 ↦ 1, 1 ↦ 1, 2 undefined, to {0, 1}
+	partial := [][]bool{{false, true}, {false, true}, {false, false}}
+	if f, err := AsFunction(partial); err != nil || !slices.Equal(f, []int{1, 1, -1}) {
+		t.Errorf("AsFunction = %v, %v", f, err)
+	}
+	if !IsPartialFunction(partial) || IsFunction(partial) || IsInjective(partial) || IsSurjective(partial) {
+		t.Errorf("partial function misclassified")
+	}
+	if !IsSurjective(partial, []int{1}) {
+		t.Errorf("partial is not onto {1}")
+	}
+}
+
+func TestFunctionErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		kind error
+	}{
+		{"not functional", second(AsFunction([][]bool{{true, true}})), ErrNotFunctional},
+		{"not injective", second(Inverse([][]bool{{true}, {true}})), ErrNotInjective},
+		{"not defined everywhere", second(Permutation([][]bool{{true, false}, {false, false}})), ErrNotDefinedEverywhere},
+		{"not square", second(Permutation([][]bool{{true, false}})), ErrNotSquare},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.err, tt.kind) {
+			t.Errorf("%s: got %v, want %v", tt.name, tt.err, tt.kind)
+		}
+	}
+
+	err := second(Permutation([][]bool{{true, false}, {false, false}}))
+	if want := "Permutation: relation is not defined everywhere: 1 has no image"; err == nil || err.Error() != want {
+		t.Errorf("got %v, want %q", err, want)
+	}
+}
+
+func second[T any](_ T, err error) error {
+	return err
+}
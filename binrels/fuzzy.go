@@ -0,0 +1,260 @@
+package binrels
+
+import "math"
+
+// Fuzzy relations grade every pair with a membership degree in [0, 1].
+// Operations take an optional t-norm or t-conorm and default to min and
+// max, which gives the classic max–min calculus.
+
+type TNorm func(x, y float64) float64
+
+type TConorm func(x, y float64) float64
+
+var (
+	MinTNorm         TNorm = math.Min
+	ProductTNorm     TNorm = func(x, y float64) float64 { return x * y }
+	LukasiewiczTNorm TNorm = func(x, y float64) float64 { return math.Max(0, x+y-1) }
+
+	MaxTConorm              TConorm = math.Max
+	ProbabilisticSumTConorm TConorm = func(x, y float64) float64 { return x + y - x*y }
+	BoundedSumTConorm       TConorm = func(x, y float64) float64 { return math.Min(1, x+y) }
+)
+
+const fuzzyEpsilon = 1e-9
+
+func tnormOrMin(t []TNorm) TNorm {
+	if len(t) > 0 && t[0] != nil {
+		return t[0]
+	}
+	return MinTNorm
+}
+
+func tconormOrMax(s []TConorm) TConorm {
+	if len(s) > 0 && s[0] != nil {
+		return s[0]
+	}
+	return MaxTConorm
+}
+
+func foreachgrade(rows, cols int, f func(i, j int) float64) [][]float64 {
+	result := FuzzyZero(rows, cols)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			result[i][j] = f(i, j)
+		}
+	}
+	return result
+}
+
+func sameFuzzyShape(a, b [][]float64) bool {
+	return len(a) == len(b) && len(a) != 0 && len(a[0]) == len(b[0])
+}
+
+func FuzzyZero(rows, cols int) [][]float64 {
+	matrix := make([][]float64, rows)
+	for i := range matrix {
+		matrix[i] = make([]float64, cols)
+	}
+	return matrix
+}
+
+func FuzzyIdentity(n int) [][]float64 {
+	matrix := FuzzyZero(n, n)
+	for i := range matrix {
+		matrix[i][i] = 1
+	}
+	return matrix
+}
+
+// FromCrisp turns an ordinary relation into a fuzzy one with grades 0 and 1.
+func FromCrisp(a [][]bool) [][]float64 {
+	if len(a) == 0 {
+		return nil
+	}
+
+	return foreachgrade(len(a), len(a[0]), func(i, j int) float64 {
+		if a[i][j] {
+			return 1
+		}
+		return 0
+	})
+}
+
+// CheckFuzzy reports ragged rows and grades outside [0, 1].
+func CheckFuzzy(a [][]float64) error {
+	for i := range a {
+		if len(a[i]) != len(a[0]) {
+			return newError("CheckFuzzy", ErrRaggedRows, "row %d has %d columns, row 0 has %d", i, len(a[i]), len(a[0]))
+		}
+		for j, v := range a[i] {
+			if !(v >= 0 && v <= 1) {
+				return newError("CheckFuzzy", ErrGradeOutOfRange, "grade %v at (%d, %d)", v, i, j)
+			}
+		}
+	}
+	return nil
+}
+
+func FuzzyUnion(a, b [][]float64, s ...TConorm) [][]float64 {
+	if !sameFuzzyShape(a, b) {
+		return nil
+	}
+
+	join := tconormOrMax(s)
+	return foreachgrade(len(a), len(a[0]), func(i, j int) float64 {
+		return join(a[i][j], b[i][j])
+	})
+}
+
+func FuzzyIntersection(a, b [][]float64, t ...TNorm) [][]float64 {
+	if !sameFuzzyShape(a, b) {
+		return nil
+	}
+
+	meet := tnormOrMin(t)
+	return foreachgrade(len(a), len(a[0]), func(i, j int) float64 {
+		return meet(a[i][j], b[i][j])
+	})
+}
+
+func FuzzyComplement(a [][]float64) [][]float64 {
+	if len(a) == 0 {
+		return nil
+	}
+
+	return foreachgrade(len(a), len(a[0]), func(i, j int) float64 {
+		return 1 - a[i][j]
+	})
+}
+
+func FuzzyTranspose(a [][]float64) [][]float64 {
+	if len(a) == 0 {
+		return nil
+	}
+
+	return foreachgrade(len(a[0]), len(a), func(i, j int) float64 {
+		return a[j][i]
+	})
+}
+
+// FuzzyComposition is the sup-t composition: the grade of (i, j) is the best
+// t(a[i][k], b[k][j]) over all k. The default t-norm gives max–min
+// composition, ProductTNorm gives max–product.
+func FuzzyComposition(a, b [][]float64, t ...TNorm) [][]float64 {
+	if len(a) == 0 || len(b) == 0 || len(a[0]) != len(b) {
+		return nil
+	}
+
+	meet := tnormOrMin(t)
+	return foreachgrade(len(a), len(b[0]), func(i, j int) float64 {
+		best := 0.0
+		for k := range b {
+			best = math.Max(best, meet(a[i][k], b[k][j]))
+		}
+		return best
+	})
+}
+
+// FuzzyTransitiveClosure returns the smallest t-transitive relation
+// containing a. A t-norm never exceeds min, so going around a cycle cannot
+// raise a grade and Warshall's algorithm applies unchanged.
+func FuzzyTransitiveClosure(a [][]float64, t ...TNorm) [][]float64 {
+	if len(a) == 0 || len(a) != len(a[0]) {
+		return nil
+	}
+
+	meet := tnormOrMin(t)
+	result := foreachgrade(len(a), len(a), func(i, j int) float64 {
+		return a[i][j]
+	})
+	for k := range result {
+		for i := range result {
+			if result[i][k] == 0 {
+				continue
+			}
+			for j := range result {
+				result[i][j] = math.Max(result[i][j], meet(result[i][k], result[k][j]))
+			}
+		}
+	}
+	return result
+}
+
+// AlphaCut keeps the pairs whose grade is at least alpha.
+func AlphaCut(a [][]float64, alpha float64) [][]bool {
+	if len(a) == 0 {
+		return nil
+	}
+
+	return foreachcell(len(a), len(a[0]), func(i, j int) bool {
+		return a[i][j] >= alpha
+	})
+}
+
+// fuzzySquare reports whether every row of a has one grade per row. The
+// fuzzy properties, like the crisp ones, are only defined for such matrices.
+func fuzzySquare(a [][]float64) bool {
+	for _, row := range a {
+		if len(row) != len(a) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsFuzzyReflexive reports whether every element is related to itself with
+// grade 1. It reports false for matrices that are not square.
+func IsFuzzyReflexive(a [][]float64) bool {
+	if !fuzzySquare(a) {
+		return false
+	}
+
+	for i := range a {
+		if math.Abs(a[i][i]-1) > fuzzyEpsilon {
+			return false
+		}
+	}
+	return true
+}
+
+// IsFuzzySymmetric reports false for matrices that are not square.
+func IsFuzzySymmetric(a [][]float64) bool {
+	if !fuzzySquare(a) {
+		return false
+	}
+
+	for i := range a {
+		for j := range a {
+			if math.Abs(a[i][j]-a[j][i]) > fuzzyEpsilon {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// IsFuzzyTransitive reports whether t(a[i][k], a[k][j]) never exceeds
+// a[i][j]. It reports false for matrices that are not square.
+func IsFuzzyTransitive(a [][]float64, t ...TNorm) bool {
+	if !fuzzySquare(a) {
+		return false
+	}
+
+	meet := tnormOrMin(t)
+	for i := range a {
+		for k := range a {
+			for j := range a {
+				if meet(a[i][k], a[k][j]) > a[i][j]+fuzzyEpsilon {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// IsSimilarity reports whether a is reflexive, symmetric and t-transitive.
+// Every α-cut of a max–min similarity is an equivalence relation.
+func IsSimilarity(a [][]float64, t ...TNorm) bool {
+	return IsFuzzyReflexive(a) && IsFuzzySymmetric(a) && IsFuzzyTransitive(a, t...)
+}
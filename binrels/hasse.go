@@ -0,0 +1,100 @@
+package binrels
+
+// TransitiveReduction returns the smallest relation with the same transitive
+// closure as a. It is unique only for acyclic relations, so any cycle,
+// including a loop, is reported as a CycleError.
+func TransitiveReduction(a [][]bool) ([][]bool, error) {
+	n, err := checkSquare("TransitiveReduction", a)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, nil
+	}
+
+	if cycle := findCycle(a); cycle != nil {
+		return nil, &CycleError{Cycle: cycle}
+	}
+	return reduce(a), nil
+}
+
+// reduce returns the transitive reduction of an acyclic relation.
+func reduce(a [][]bool) [][]bool {
+	closure := TransitiveClosure(a)
+	return Diff(closure, Composition(closure, closure))
+}
+
+func strictPart(a [][]bool) [][]bool {
+	return Diff(a, Identity(len(a)))
+}
+
+type Hasse struct {
+	// Covers lists every pair (x, y) where y covers x, ordered by x then y.
+	Covers []Pair
+	// Levels holds the rank of each element: minimal elements are on level
+	// 0 and every other element sits one level above its highest lower cover.
+	Levels []int
+}
+
+// HasseDiagram extracts the covering relation of an order. Loops are
+// ignored, so both reflexive and strict orders are accepted; any longer
+// cycle is reported as a CycleError, as in TopologicalSort.
+func HasseDiagram(a [][]bool) (*Hasse, error) {
+	strict, err := orderGraph("HasseDiagram", a)
+	if err != nil {
+		return nil, err
+	}
+	if len(a) == 0 {
+		return &Hasse{}, nil
+	}
+
+	cover := reduce(strict)
+
+	h := &Hasse{
+		Covers: make([]Pair, 0),
+		Levels: make([]int, len(a)),
+	}
+	for x := range cover {
+		for _, y := range BottomIntersection(cover, x) {
+			h.Covers = append(h.Covers, Pair{x, y})
+		}
+	}
+
+	placed := make([]bool, len(a))
+	for level, left := 0, len(a); left > 0; level++ {
+		layer := make([]int, 0)
+		for y := range cover {
+			if placed[y] {
+				continue
+			}
+
+			minimal := true
+			for _, x := range TopIntersection(cover, y) {
+				if !placed[x] {
+					minimal = false
+					break
+				}
+			}
+			if minimal {
+				layer = append(layer, y)
+			}
+		}
+
+		for _, y := range layer {
+			placed[y] = true
+			h.Levels[y] = level
+		}
+		left -= len(layer)
+	}
+
+	return h, nil
+}
+
+// Matrix returns the covering relation as a matrix over n elements.
+func (h *Hasse) Matrix() [][]bool {
+	result := Zero(len(h.Levels))
+	for _, p := range h.Covers {
+		result[p.I][p.J] = true
+	}
+	return result
+}
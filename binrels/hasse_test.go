@@ -0,0 +1,54 @@
+package binrels
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestHasseDiagram(t *testing.T) {
+	// 0 < 1, 0 < 2, 1 < 3, 2 < 3 as a reflexive order
+	cover := Zero(4)
+	cover[0][1], cover[0][2], cover[1][3], cover[2][3] = true, true, true, true
+	h, err := HasseDiagram(Reachability(cover))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []Pair{{0, 1}, {0, 2}, {1, 3}, {2, 3}}; !slices.Equal(h.Covers, want) {
+		t.Errorf("Covers = %v, want %v", h.Covers, want)
+	}
+	if want := []int{0, 1, 1, 2}; !slices.Equal(h.Levels, want) {
+		t.Errorf("Levels = %v, want %v", h.Levels, want)
+	}
+	if !Equal(h.Matrix(), cover) {
+		t.Errorf("Matrix = %v, want %v", h.Matrix(), cover)
+	}
+
+	reduced, err := TransitiveReduction(TransitiveClosure(cover))
+	if err != nil || !Equal(reduced, cover) {
+		t.Errorf("TransitiveReduction = %v, %v, want %v", reduced, err, cover)
+	}
+}
+
+func TestHasseDiagramCycles(t *testing.T) {
+	twoCycle := [][]bool{{false, true}, {true, false}}
+	loop := [][]bool{{true, false}, {false, false}}
+
+	var ce *CycleError
+	if _, err := HasseDiagram(twoCycle); !errors.As(err, &ce) || len(ce.Cycle) != 2 {
+		t.Errorf("HasseDiagram of a 2-cycle: %v", err)
+	}
+	if _, err := TransitiveReduction(twoCycle); !errors.As(err, &ce) || len(ce.Cycle) != 2 {
+		t.Errorf("TransitiveReduction of a 2-cycle: %v", err)
+	}
+	if _, err := TransitiveReduction(loop); !errors.As(err, &ce) || !slices.Equal(ce.Cycle, []int{0}) {
+		t.Errorf("TransitiveReduction of a loop: %v", err)
+	}
+	if _, err := HasseDiagram(loop); err != nil {
+		t.Errorf("HasseDiagram rejects a loop: %v", err)
+	}
+	if _, err := HasseDiagram(ZeroRect(2, 3)); !errors.Is(err, ErrNotSquare) {
+		t.Errorf("HasseDiagram of a 2x3 relation: %v", err)
+	}
+}
@@ -0,0 +1,293 @@
+package binrels
+
+import (
+	"encoding/hex"
+	"slices"
+	"strconv"
+)
+
+// Canonical forms are found by individualization and refinement: elements
+// are colored by how they relate to the other colors until the coloring is
+// stable, then an element of the first ambiguous color is singled out and
+// the refinement repeats. Every complete coloring is a candidate labeling and
+// the smallest relabeled matrix wins. Labelings that produce the same matrix
+// reveal automorphisms, which prune branches known to lead to the same
+// matrices.
+
+// refine splits colors until every element of a color has the same number
+// of successors and predecessors in every color. New colors are numbered by
+// sorted signatures, so the result depends only on the structure of a.
+func refine(a [][]bool, colors []int) []int {
+	n := len(a)
+	k := slices.Max(colors) + 1
+	for {
+		sigs := make([][]int, n)
+		for x := 0; x < n; x++ {
+			sig := make([]int, 2+2*k)
+			sig[0] = colors[x]
+			if a[x][x] {
+				sig[1] = 1
+			}
+			for y := 0; y < n; y++ {
+				if a[x][y] {
+					sig[2+colors[y]]++
+				}
+				if a[y][x] {
+					sig[2+k+colors[y]]++
+				}
+			}
+			sigs[x] = sig
+		}
+
+		sorted := slices.Clone(sigs)
+		slices.SortFunc(sorted, slices.Compare)
+		sorted = slices.CompactFunc(sorted, slices.Equal)
+
+		next := make([]int, n)
+		for x, sig := range sigs {
+			next[x], _ = slices.BinarySearchFunc(sorted, sig, slices.Compare)
+		}
+
+		colors = next
+		if len(sorted) == k {
+			return colors
+		}
+		k = len(sorted)
+	}
+}
+
+// individualize gives v a color of its own just below the rest of its
+// former color.
+func individualize(colors []int, v int) []int {
+	c := colors[v]
+	next := make([]int, len(colors))
+	for x, color := range colors {
+		switch {
+		case x == v:
+			next[x] = c
+		case color >= c:
+			next[x] = color + 1
+		default:
+			next[x] = color
+		}
+	}
+	return next
+}
+
+type leaf struct {
+	form  *Relation
+	label []int
+	path  []int
+}
+
+type canonSearch struct {
+	a     [][]bool
+	first *leaf
+	best  *leaf
+	autos [][]int
+}
+
+// automorphism returns the permutation that maps every element to the one
+// holding its position under other. It is an automorphism of a whenever both
+// leaves have the same form.
+func (l *leaf) automorphism(other *leaf) []int {
+	inverse := make([]int, len(other.label))
+	for x, pos := range other.label {
+		inverse[pos] = x
+	}
+	auto := make([]int, len(l.label))
+	for x, pos := range l.label {
+		auto[x] = inverse[pos]
+	}
+	return auto
+}
+
+// jumpTo returns the depth at which l's path leaves the path of an earlier
+// leaf with the same form, provided the automorphism between them fixes the
+// shared prefix and maps l's branch onto the explored one. The rest of l's
+// branch then only repeats matrices already seen. It returns -1 otherwise.
+func (l *leaf) jumpTo(other *leaf, auto []int) int {
+	d := 0
+	for d < len(l.path) && d < len(other.path) && l.path[d] == other.path[d] {
+		d++
+	}
+	if d == len(l.path) || d == len(other.path) {
+		return -1
+	}
+
+	for _, x := range l.path[:d] {
+		if auto[x] != x {
+			return -1
+		}
+	}
+	if auto[l.path[d]] != other.path[d] {
+		return -1
+	}
+	return d
+}
+
+func (cs *canonSearch) visitLeaf(label, path []int) int {
+	n := len(cs.a)
+	form := NewRelation(n)
+	for i := 0; i < n; i++ {
+		for _, j := range BottomIntersection(cs.a, i) {
+			form.Add(label[i], label[j])
+		}
+	}
+
+	l := &leaf{form: form, label: label, path: path}
+	if cs.first == nil {
+		cs.first, cs.best = l, l
+		return -1
+	}
+
+	for _, seen := range []*leaf{cs.first, cs.best} {
+		if slices.Equal(form.words, seen.form.words) {
+			auto := l.automorphism(seen)
+			cs.autos = append(cs.autos, auto)
+			return l.jumpTo(seen, auto)
+		}
+	}
+
+	if slices.Compare(form.words, cs.best.form.words) < 0 {
+		cs.best = l
+	}
+	return -1
+}
+
+// sameOrbit reports whether a known automorphism fixing every element of
+// path maps some element of explored to v.
+func (cs *canonSearch) sameOrbit(path, explored []int, v int) bool {
+	parent := make([]int, len(cs.a))
+	for x := range parent {
+		parent[x] = x
+	}
+	find := func(x int) int {
+		for parent[x] != x {
+			parent[x] = parent[parent[x]]
+			x = parent[x]
+		}
+		return x
+	}
+
+	for _, auto := range cs.autos {
+		fixes := true
+		for _, p := range path {
+			if auto[p] != p {
+				fixes = false
+				break
+			}
+		}
+		if !fixes {
+			continue
+		}
+		for x, y := range auto {
+			parent[find(x)] = find(y)
+		}
+	}
+
+	for _, u := range explored {
+		if find(u) == find(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// search explores the node reached by individualizing path. It returns the
+// depth the search should unwind to, or -1 to carry on normally.
+func (cs *canonSearch) search(colors, path []int) int {
+	colors = refine(cs.a, colors)
+
+	// The first color shared by several elements is the one to split.
+	sizes := make([]int, len(colors))
+	for _, c := range colors {
+		sizes[c]++
+	}
+	target := slices.IndexFunc(sizes, func(size int) bool {
+		return size > 1
+	})
+	if target == -1 {
+		return cs.visitLeaf(colors, path)
+	}
+
+	explored := make([]int, 0, sizes[target])
+	for v, c := range colors {
+		if c != target || cs.sameOrbit(path, explored, v) {
+			continue
+		}
+		explored = append(explored, v)
+
+		jump := cs.search(individualize(colors, v), append(slices.Clone(path), v))
+		if jump != -1 && jump < len(path) {
+			return jump
+		}
+	}
+	return -1
+}
+
+// CanonicalForm relabels a so that relations that are equal up to renaming
+// their elements get the same matrix. The labeling p places element x at
+// position p[x]: form[p[x]][p[y]] == a[x][y]. It returns nil for relations
+// that are not square.
+func CanonicalForm(a [][]bool) ([][]bool, []int) {
+	n, err := checkSquare("CanonicalForm", a)
+	if err != nil {
+		return nil, nil
+	}
+	if n == 0 {
+		return Zero(0), []int{}
+	}
+
+	cs := &canonSearch{a: a}
+	cs.search(make([]int, n), nil)
+	return cs.best.form.Matrix(), cs.best.label
+}
+
+// CanonicalKey encodes the canonical form of a as a string, suitable as a
+// map key for deduplicating relations up to isomorphism.
+func CanonicalKey(a [][]bool) string {
+	form, _ := CanonicalForm(a)
+	if form == nil {
+		return ""
+	}
+
+	r := FromMatrix(form)
+	buf := make([]byte, 0, len(r.words)*8)
+	for _, w := range r.words {
+		for b := 0; b < 8; b++ {
+			buf = append(buf, byte(w>>(8*b)))
+		}
+	}
+	return strconv.Itoa(len(form)) + ":" + hex.EncodeToString(buf)
+}
+
+// Isomorphism returns a permutation p with b[p[x]][p[y]] == a[x][y] for all
+// x and y, if there is one.
+func Isomorphism(a, b [][]bool) ([]int, bool) {
+	if len(a) != len(b) || FromMatrix(a).Count() != FromMatrix(b).Count() {
+		return nil, false
+	}
+
+	formA, labelA := CanonicalForm(a)
+	formB, labelB := CanonicalForm(b)
+	if formA == nil || formB == nil || !Equal(formA, formB) {
+		return nil, false
+	}
+
+	inverseB := make([]int, len(labelB))
+	for x, l := range labelB {
+		inverseB[l] = x
+	}
+
+	p := make([]int, len(labelA))
+	for x, l := range labelA {
+		p[x] = inverseB[l]
+	}
+	return p, true
+}
+
+func IsIsomorphic(a, b [][]bool) bool {
+	_, ok := Isomorphism(a, b)
+	return ok
+}
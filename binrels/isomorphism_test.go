@@ -0,0 +1,85 @@
+package binrels
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// relabel returns the relation b with b[p[x]][p[y]] == a[x][y].
+func relabel(a [][]bool, p []int) [][]bool {
+	b := Zero(len(a))
+	for x := range a {
+		for y := range a[x] {
+			b[p[x]][p[y]] = a[x][y]
+		}
+	}
+	return b
+}
+
+func fromBits(n int, bits uint64) [][]bool {
+	return foreachcell(n, n, func(i int, j int) bool {
+		return bits&(1<<(i*n+j)) != 0
+	})
+}
+
+func TestCanonicalKeyCountsClasses(t *testing.T) {
+	// Relations on n unlabeled elements, OEIS A000595.
+	for n, want := range []int{1, 2, 10, 104, 3044} {
+		if testing.Short() && n == 4 {
+			continue
+		}
+
+		keys := make(map[string]bool)
+		for bits := uint64(0); bits < 1<<(n*n); bits++ {
+			keys[CanonicalKey(fromBits(n, bits))] = true
+		}
+		if len(keys) != want {
+			t.Errorf("%d classes of relations on %d elements, want %d", len(keys), n, want)
+		}
+	}
+}
+
+func TestCanonicalFormUnderRelabeling(t *testing.T) {
+	rng := rand.New(rand.NewPCG(22, 22))
+	for trial := 0; trial < 500; trial++ {
+		n := 1 + rng.IntN(12)
+		a := randomMatrix(rng, n, n, rng.Float64())
+		b := relabel(a, rng.Perm(n))
+
+		form, label := CanonicalForm(a)
+		if !Equal(relabel(a, label), form) {
+			t.Fatalf("CanonicalForm of %v does not match its labeling %v", a, label)
+		}
+		if CanonicalKey(a) != CanonicalKey(b) {
+			t.Fatalf("relabeling %v as %v changes its key", a, b)
+		}
+
+		p, ok := Isomorphism(a, b)
+		if !ok {
+			t.Fatalf("Isomorphism(%v, %v) found none", a, b)
+		}
+		if !Equal(relabel(a, p), b) {
+			t.Fatalf("Isomorphism(%v, %v) = %v, which does not map a onto b", a, b, p)
+		}
+	}
+}
+
+func TestIsomorphismRejects(t *testing.T) {
+	chain := [][]bool{{false, true, false}, {false, false, true}, {false, false, false}}
+	fork := [][]bool{{false, true, true}, {false, false, false}, {false, false, false}}
+	cycle := [][]bool{{false, true, false}, {false, false, true}, {true, false, false}}
+
+	for _, tc := range []struct {
+		name string
+		a, b [][]bool
+	}{
+		{"same count", chain, Transpose(fork)},
+		{"different count", chain, cycle},
+		{"different size", chain, Zero(2)},
+		{"not square", ZeroRect(2, 3), ZeroRect(2, 3)},
+	} {
+		if p, ok := Isomorphism(tc.a, tc.b); ok {
+			t.Errorf("%s: Isomorphism(%v, %v) = %v", tc.name, tc.a, tc.b, p)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package binrels
+
+import "slices"
+
+// LabeledRelation is a relation over an explicit universe of elements. It
+// keeps the universe order fixed and delegates every operation to the
+// matrix functions, translating indices back to elements on the way out.
+type LabeledRelation[T comparable] struct {
+	universe []T
+	index    map[T]int
+	matrix   [][]bool
+}
+
+// NewLabeledRelation returns an empty relation over universe. Repeated
+// elements are kept only once, at their first position.
+func NewLabeledRelation[T comparable](universe []T) *LabeledRelation[T] {
+	l := &LabeledRelation[T]{
+		universe: make([]T, 0, len(universe)),
+		index:    make(map[T]int, len(universe)),
+	}
+	for _, x := range universe {
+		if _, ok := l.index[x]; ok {
+			continue
+		}
+		l.index[x] = len(l.universe)
+		l.universe = append(l.universe, x)
+	}
+	l.matrix = Zero(len(l.universe))
+	return l
+}
+
+// LabelMatrix attaches universe to an existing matrix. The matrix is copied
+// and must be square with one row per element of universe.
+func LabelMatrix[T comparable](universe []T, a [][]bool) *LabeledRelation[T] {
+	l := NewLabeledRelation(universe)
+	if len(l.universe) != len(universe) || len(a) != len(universe) {
+		return nil
+	}
+	for i := range a {
+		if len(a[i]) != len(a) {
+			return nil
+		}
+		copy(l.matrix[i], a[i])
+	}
+	return l
+}
+
+func (l *LabeledRelation[T]) derive(m [][]bool) *LabeledRelation[T] {
+	if m == nil && len(l.universe) > 0 {
+		return nil
+	}
+	return &LabeledRelation[T]{universe: l.universe, index: l.index, matrix: m}
+}
+
+func (l *LabeledRelation[T]) compatible(m *LabeledRelation[T]) bool {
+	return m != nil && slices.Equal(l.universe, m.universe)
+}
+
+func (l *LabeledRelation[T]) labels(indices []int) []T {
+	if indices == nil {
+		return nil
+	}
+
+	res := make([]T, len(indices))
+	for i, x := range indices {
+		res[i] = l.universe[x]
+	}
+	return res
+}
+
+func (l *LabeledRelation[T]) Universe() []T {
+	return slices.Clone(l.universe)
+}
+
+func (l *LabeledRelation[T]) Index(x T) (int, bool) {
+	i, ok := l.index[x]
+	return i, ok
+}
+
+func (l *LabeledRelation[T]) Matrix() [][]bool {
+	return Copy(l.matrix)
+}
+
+// Add relates x to y and reports whether both belong to the universe.
+func (l *LabeledRelation[T]) Add(x, y T) bool {
+	i, okX := l.index[x]
+	j, okY := l.index[y]
+	if !okX || !okY {
+		return false
+	}
+	l.matrix[i][j] = true
+	return true
+}
+
+func (l *LabeledRelation[T]) Remove(x, y T) {
+	i, okX := l.index[x]
+	j, okY := l.index[y]
+	if okX && okY {
+		l.matrix[i][j] = false
+	}
+}
+
+func (l *LabeledRelation[T]) Has(x, y T) bool {
+	i, okX := l.index[x]
+	j, okY := l.index[y]
+	return okX && okY && l.matrix[i][j]
+}
+
+// Pairs lists the related pairs in universe order.
+func (l *LabeledRelation[T]) Pairs() [][2]T {
+	res := make([][2]T, 0)
+	for i := range l.matrix {
+		for _, j := range BottomIntersection(l.matrix, i) {
+			res = append(res, [2]T{l.universe[i], l.universe[j]})
+		}
+	}
+	return res
+}
+
+func (l *LabeledRelation[T]) Union(m *LabeledRelation[T]) *LabeledRelation[T] {
+	if !l.compatible(m) {
+		return nil
+	}
+	return l.derive(Union(l.matrix, m.matrix))
+}
+
+func (l *LabeledRelation[T]) Intersection(m *LabeledRelation[T]) *LabeledRelation[T] {
+	if !l.compatible(m) {
+		return nil
+	}
+	return l.derive(Intersection(l.matrix, m.matrix))
+}
+
+func (l *LabeledRelation[T]) Diff(m *LabeledRelation[T]) *LabeledRelation[T] {
+	if !l.compatible(m) {
+		return nil
+	}
+	return l.derive(Diff(l.matrix, m.matrix))
+}
+
+func (l *LabeledRelation[T]) SymmDiff(m *LabeledRelation[T]) *LabeledRelation[T] {
+	if !l.compatible(m) {
+		return nil
+	}
+	return l.derive(SymmDiff(l.matrix, m.matrix))
+}
+
+func (l *LabeledRelation[T]) Composition(m *LabeledRelation[T]) *LabeledRelation[T] {
+	if !l.compatible(m) {
+		return nil
+	}
+	return l.derive(Composition(l.matrix, m.matrix))
+}
+
+func (l *LabeledRelation[T]) Power(n int) *LabeledRelation[T] {
+	return l.derive(Copy(Power(l.matrix, n)))
+}
+
+func (l *LabeledRelation[T]) Transpose() *LabeledRelation[T] {
+	return l.derive(Transpose(l.matrix))
+}
+
+func (l *LabeledRelation[T]) Complement() *LabeledRelation[T] {
+	return l.derive(Complement(l.matrix))
+}
+
+func (l *LabeledRelation[T]) TransitiveClosure() *LabeledRelation[T] {
+	return l.derive(TransitiveClosure(l.matrix))
+}
+
+func (l *LabeledRelation[T]) Reachability() *LabeledRelation[T] {
+	return l.derive(Reachability(l.matrix))
+}
+
+func (l *LabeledRelation[T]) MutualReachability() *LabeledRelation[T] {
+	return l.derive(MutualReachability(l.matrix))
+}
+
+func (l *LabeledRelation[T]) DefinitionDomain() []T {
+	return l.labels(DefinitionDomain(l.matrix))
+}
+
+func (l *LabeledRelation[T]) MeaningDomain() []T {
+	return l.labels(MeaningDomain(l.matrix))
+}
+
+func (l *LabeledRelation[T]) BottomIntersection(x T) []T {
+	i, ok := l.index[x]
+	if !ok {
+		return nil
+	}
+	return l.labels(BottomIntersection(l.matrix, i))
+}
+
+func (l *LabeledRelation[T]) TopIntersection(x T) []T {
+	i, ok := l.index[x]
+	if !ok {
+		return nil
+	}
+	return l.labels(TopIntersection(l.matrix, i))
+}
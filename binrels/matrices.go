@@ -1,16 +1,13 @@
 package binrels
 
-import (
-	"fmt"
-	"strings"
-)
+import "os"
 
 func Copy(a [][]bool) [][]bool {
 	if len(a) == 0 {
 		return nil
 	}
 
-	return foreachcell(len(a), func(i int, j int) bool {
+	return foreachcell(len(a), len(a[0]), func(i int, j int) bool {
 		return a[i][j]
 	})
 }
@@ -36,9 +33,13 @@ func Equal(a, b [][]bool) bool {
 }
 
 func Zero(n int) [][]bool {
-	matrix := make([][]bool, n)
+	return ZeroRect(n, n)
+}
+
+func ZeroRect(rows, cols int) [][]bool {
+	matrix := make([][]bool, rows)
 	for i := range matrix {
-		matrix[i] = make([]bool, n)
+		matrix[i] = make([]bool, cols)
 	}
 	return matrix
 }
@@ -51,55 +52,22 @@ func Identity(n int) [][]bool {
 	return matrix
 }
 
+// PrintWithSource prints the block of relationship covered by source, one
+// name per row and column. It panics when relationship is too small for the
+// names; PrintWithSourceChecked reports that as an error instead.
 func PrintWithSource(source []string, relationship [][]bool) {
-	minSourceNameLen := 0
-	for _, s := range source {
-		if len(s) > minSourceNameLen {
-			minSourceNameLen = len(s)
-		}
-	}
-
-	fmt.Printf("%-*s | ", minSourceNameLen, "")
-	for i := range source {
-		fmt.Printf("%-*s", minSourceNameLen, source[i])
-		if i < len(source)-1 {
-			fmt.Printf("| ")
-		}
-	}
+	block := make([][]bool, len(source))
 	for i := range source {
-		fmt.Println()
-		fmt.Println("--------------------")
-		fmt.Printf(" %-*s| ", minSourceNameLen, source[i])
-		for j := range source {
-			if relationship[i][j] {
-				fmt.Printf("%-*s", minSourceNameLen, "1")
-			} else {
-				fmt.Printf("%-*s", minSourceNameLen, "0")
-			}
-			if j < len(source)-1 {
-				fmt.Printf("| ")
-			}
+		if i >= len(relationship) || len(relationship[i]) < len(source) {
+			panic(newError("PrintWithSource", ErrDimensionMismatch, "%d names for a relation with %d rows", len(source), len(relationship)))
 		}
+		block[i] = relationship[i][:len(source)]
 	}
-	fmt.Println()
+
+	// Write errors are ignored, as fmt.Printf does.
+	_ = Fprint(os.Stdout, block, FormatOptions{Labels: append([]string{}, source...)})
 }
 
 func Print(relationship [][]bool) {
-	for i := range relationship {
-		fmt.Println()
-		if i != 0 {
-			fmt.Println(strings.Repeat("----", len(relationship[i])))
-		}
-		for j := range relationship[i] {
-			if relationship[i][j] {
-				fmt.Printf(" 1 ")
-			} else {
-				fmt.Printf(" 0 ")
-			}
-			if j < len(relationship[i])-1 {
-				fmt.Printf("|")
-			}
-		}
-	}
-	fmt.Println()
+	_ = Fprint(os.Stdout, relationship)
 }
@@ -1,66 +1,65 @@
 package binrels
 
-func foreachcell(size int, f func(i, j int) bool) [][]bool {
-	result := Zero(size)
-	for i := 0; i < size; i++ {
-		for j := 0; j < size; j++ {
+func foreachcell(rows, cols int, f func(i, j int) bool) [][]bool {
+	result := ZeroRect(rows, cols)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
 			result[i][j] = f(i, j)
 		}
 	}
 	return result
 }
 
+func sameShape(a, b [][]bool) bool {
+	return len(a) == len(b) && len(a) != 0 && len(a[0]) == len(b[0])
+}
+
 func Intersection(a, b [][]bool) [][]bool {
-	if len(a) != len(b) || len(a) == 0 || len(a[0]) != len(b[0]) {
+	if !sameShape(a, b) {
 		return nil
 	}
 
-	return foreachcell(len(a), func(i int, j int) bool {
+	return foreachcell(len(a), len(a[0]), func(i int, j int) bool {
 		return a[i][j] && b[i][j]
 	})
 }
 
 func Union(a, b [][]bool) [][]bool {
-	if len(a) != len(b) || len(a) == 0 || len(a[0]) != len(b[0]) {
+	if !sameShape(a, b) {
 		return nil
 	}
 
-	return foreachcell(len(a), func(i int, j int) bool {
+	return foreachcell(len(a), len(a[0]), func(i int, j int) bool {
 		return a[i][j] || b[i][j]
 	})
 }
 
 func Diff(a, b [][]bool) [][]bool {
-	if len(a) != len(b) || len(a) == 0 || len(a[0]) != len(b[0]) {
+	if !sameShape(a, b) {
 		return nil
 	}
 
-	return foreachcell(len(a), func(i int, j int) bool {
+	return foreachcell(len(a), len(a[0]), func(i int, j int) bool {
 		return a[i][j] && !b[i][j]
 	})
 }
 
 func SymmDiff(a, b [][]bool) [][]bool {
-	if len(a) != len(b) || len(a) == 0 || len(a[0]) != len(b[0]) {
+	if !sameShape(a, b) {
 		return nil
 	}
 
-	return foreachcell(len(a), func(i int, j int) bool {
+	return foreachcell(len(a), len(a[0]), func(i int, j int) bool {
 		return a[i][j] && !b[i][j] || !a[i][j] && b[i][j]
 	})
 }
 
+// Composition relates i to j when a relates i to some k and b relates that k
+// to j, so an A×B relation composed with a B×C relation yields an A×C one.
 func Composition(a, b [][]bool) [][]bool {
-	if len(a) != len(b) || len(a) == 0 || len(a[0]) != len(b[0]) {
+	if len(a) == 0 || len(b) == 0 || len(a[0]) != len(b) {
 		return nil
 	}
 
-	return foreachcell(len(a), func(i int, j int) bool {
-		for k := 0; k < len(a); k++ {
-			if a[i][k] && b[k][j] {
-				return true
-			}
-		}
-		return false
-	})
+	return FromMatrix(a).Composition(FromMatrix(b)).Matrix()
 }
@@ -0,0 +1,138 @@
+package binrels
+
+import (
+	"context"
+	"math/bits"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// rowBlock is how many rows a worker claims at a time. Workers check for
+// cancellation between blocks.
+const rowBlock = 64
+
+// parallelRows calls f for every row in [0, rows), spreading blocks of rows
+// over workers goroutines. workers <= 0 means one per available CPU.
+func parallelRows(ctx context.Context, rows, workers int, f func(i int)) error {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, (rows+rowBlock-1)/rowBlock)
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				start := int(next.Add(rowBlock)) - rowBlock
+				if start >= rows {
+					return
+				}
+				for i := start; i < min(start+rowBlock, rows); i++ {
+					f(i)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return ctx.Err()
+}
+
+// CompositionContext is Composition with the rows of the result computed by
+// several workers using the row-OR kernel. Every kernel yields the same
+// relation, so the result is identical to Composition.
+func (r *Relation) CompositionContext(ctx context.Context, s *Relation, workers int) (*Relation, error) {
+	if s == nil || r.cols != s.rows {
+		return nil, newError("Composition", ErrDimensionMismatch, "left has %d columns, right has %d rows", r.cols, relationRows(s))
+	}
+
+	result := newRelation(r.rows, s.cols)
+	err := parallelRows(ctx, r.rows, workers, func(i int) {
+		composeRow(result, r, s, i)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func relationRows(r *Relation) int {
+	if r == nil {
+		return 0
+	}
+	return r.rows
+}
+
+func (r *Relation) PowerContext(ctx context.Context, n, workers int) (*Relation, error) {
+	if r.rows != r.cols {
+		return nil, newError("Power", ErrNotSquare, "%dx%d", r.rows, r.cols)
+	}
+	if n < 0 {
+		return nil, newError("Power", ErrNegativePower, "%d", n)
+	}
+
+	result := IdentityRelation(r.rows)
+	base := r.Clone()
+	for ; n > 0; n >>= 1 {
+		var err error
+		if n&1 == 1 {
+			if result, err = result.CompositionContext(ctx, base, workers); err != nil {
+				return nil, err
+			}
+		}
+		if n > 1 {
+			if base, err = base.CompositionContext(ctx, base, workers); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return result, nil
+}
+
+// TransitiveClosureContext computes every row of the closure independently:
+// a search from i keeps ORing in the rows of newly reached elements until
+// nothing new turns up. That costs the same O(n³/64) as Warshall's algorithm
+// but needs no synchronisation between rows.
+func (r *Relation) TransitiveClosureContext(ctx context.Context, workers int) (*Relation, error) {
+	if r.rows != r.cols {
+		return nil, newError("TransitiveClosure", ErrNotSquare, "%dx%d", r.rows, r.cols)
+	}
+
+	result := r.Clone()
+	err := parallelRows(ctx, r.rows, workers, func(i int) {
+		reached := result.row(i)
+		frontier := make([]uint64, r.stride)
+		copy(frontier, reached)
+
+		fresh := make([]uint64, r.stride)
+		for {
+			clear(fresh)
+			for w, word := range frontier {
+				for word != 0 {
+					k := w*wordBits + bits.TrailingZeros64(word)
+					word &= word - 1
+					orInto(fresh, r.row(k))
+				}
+			}
+
+			grown := false
+			for w := range fresh {
+				fresh[w] &^= reached[w]
+				reached[w] |= fresh[w]
+				grown = grown || fresh[w] != 0
+			}
+			if !grown {
+				return
+			}
+			frontier, fresh = fresh, frontier
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
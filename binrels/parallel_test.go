@@ -0,0 +1,88 @@
+package binrels
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"testing"
+)
+
+// parallelSizes straddle rowBlock so that some runs use several workers and
+// partial blocks.
+var parallelSizes = []int{0, 1, 5, 63, 64, 65, 129, 200}
+
+func TestContextVariantsMatchSequential(t *testing.T) {
+	rng := rand.New(rand.NewPCG(20, 20))
+	ctx := context.Background()
+
+	for trial := 0; trial < 60; trial++ {
+		n := parallelSizes[rng.IntN(len(parallelSizes))]
+		cols := parallelSizes[rng.IntN(len(parallelSizes))]
+		workers := rng.IntN(5)
+		density := 2 / float64(n+1)
+		r := FromMatrix(randomMatrix(rng, n, n, density))
+		s := FromMatrix(randomMatrix(rng, n, cols, density))
+		if n == 0 {
+			s = newRelation(0, 0)
+		}
+
+		got, err := r.CompositionContext(ctx, s, workers)
+		if err != nil {
+			t.Fatalf("CompositionContext: %v", err)
+		}
+		if want := r.Composition(s); !got.Equal(want) {
+			t.Fatalf("CompositionContext differs from Relation.Composition for %dx%d ∘ %dx%d with %d workers", n, n, s.rows, s.cols, workers)
+		}
+		if want := FromMatrix(Composition(r.Matrix(), s.Matrix())); n > 0 && !got.Equal(want) {
+			t.Fatalf("CompositionContext differs from Composition for %dx%d ∘ %dx%d with %d workers", n, n, s.rows, s.cols, workers)
+		}
+
+		k := rng.IntN(6)
+		got, err = r.PowerContext(ctx, k, workers)
+		if err != nil {
+			t.Fatalf("PowerContext: %v", err)
+		}
+		if want := FromMatrix(Power(r.Matrix(), k)); n > 0 && !got.Equal(want) {
+			t.Fatalf("PowerContext(%d) differs from Power for n = %d with %d workers", k, n, workers)
+		}
+
+		got, err = r.TransitiveClosureContext(ctx, workers)
+		if err != nil {
+			t.Fatalf("TransitiveClosureContext: %v", err)
+		}
+		if want := FromMatrix(TransitiveClosure(r.Matrix())); n > 0 && !got.Equal(want) {
+			t.Fatalf("TransitiveClosureContext differs from TransitiveClosure for n = %d with %d workers", n, workers)
+		}
+	}
+}
+
+func TestContextVariantsCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	rng := rand.New(rand.NewPCG(20, 1))
+	r := FromMatrix(randomMatrix(rng, 300, 300, 0.01))
+
+	if _, err := r.CompositionContext(ctx, r, 2); !errors.Is(err, context.Canceled) {
+		t.Errorf("CompositionContext after cancel: got %v, want %v", err, context.Canceled)
+	}
+	if _, err := r.PowerContext(ctx, 5, 2); !errors.Is(err, context.Canceled) {
+		t.Errorf("PowerContext after cancel: got %v, want %v", err, context.Canceled)
+	}
+	if _, err := r.TransitiveClosureContext(ctx, 2); !errors.Is(err, context.Canceled) {
+		t.Errorf("TransitiveClosureContext after cancel: got %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestCompositionContextEmpty(t *testing.T) {
+	r := newRelation(0, 3)
+	s := newRelation(3, 2)
+
+	got, err := r.CompositionContext(context.Background(), s, 0)
+	if err != nil {
+		t.Fatalf("CompositionContext: %v", err)
+	}
+	if want := r.Composition(s); !got.Equal(want) {
+		t.Errorf("CompositionContext of an empty relation = %v, want %v", got, want)
+	}
+}
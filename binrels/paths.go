@@ -0,0 +1,105 @@
+package binrels
+
+// Chains here are sequences x0 → x1 → … → xk of related elements with k ≥ 1,
+// so a chain from an element back to itself is a cycle through it.
+
+// shortestFrom runs a breadth-first search from x and returns, for every
+// element, its predecessor on a shortest chain from x and that chain's
+// length, or -1 when it cannot be reached.
+func shortestFrom(a [][]bool, x int) (prev, dist []int) {
+	prev = make([]int, len(a))
+	dist = make([]int, len(a))
+	for y := range dist {
+		prev[y], dist[y] = -1, -1
+	}
+
+	queue := make([]int, 0, len(a))
+	for _, y := range BottomIntersection(a, x) {
+		prev[y], dist[y] = x, 1
+		queue = append(queue, y)
+	}
+	for len(queue) > 0 {
+		y := queue[0]
+		queue = queue[1:]
+		for _, z := range BottomIntersection(a, y) {
+			if dist[z] == -1 {
+				prev[z], dist[z] = y, dist[y]+1
+				queue = append(queue, z)
+			}
+		}
+	}
+	return prev, dist
+}
+
+// ShortestChain returns a shortest chain from i to j as the list of its
+// elements, starting with i and ending with j. It reports false for
+// relations that are not square.
+func ShortestChain(a [][]bool, i, j int) ([]int, bool) {
+	if _, err := checkSquare("ShortestChain", a); err != nil {
+		return nil, false
+	}
+	if i < 0 || i >= len(a) || j < 0 || j >= len(a) {
+		return nil, false
+	}
+
+	prev, dist := shortestFrom(a, i)
+	if dist[j] == -1 {
+		return nil, false
+	}
+
+	chain := make([]int, dist[j]+1)
+	chain[0] = i
+	for k, y := dist[j], j; k > 0; k-- {
+		chain[k] = y
+		y = prev[y]
+	}
+	return chain, true
+}
+
+// PathLengths returns the length of a shortest chain between every pair of
+// elements, or -1 when there is none. The diagonal holds the length of the
+// shortest cycle through each element. It returns nil for relations that
+// are not square.
+func PathLengths(a [][]bool) [][]int {
+	if n, err := checkSquare("PathLengths", a); err != nil || n == 0 {
+		return nil
+	}
+
+	lengths := make([][]int, len(a))
+	for x := range a {
+		_, lengths[x] = shortestFrom(a, x)
+	}
+	return lengths
+}
+
+// FindCycle returns the elements of some cycle of a in order, the last one
+// being related to the first. A loop counts as a cycle of one element. It
+// reports false for relations that are not square.
+func FindCycle(a [][]bool) ([]int, bool) {
+	if _, err := checkSquare("FindCycle", a); err != nil {
+		return nil, false
+	}
+
+	cycle := findCycle(a)
+	return cycle, cycle != nil
+}
+
+// LongestChain returns the number of pairs on a longest chain of an acyclic
+// relation. Loops are ignored, as in TopologicalSort, so a reflexive order
+// has the same longest chain as its strict part.
+func LongestChain(a [][]bool) (int, error) {
+	strict, err := orderGraph("LongestChain", a)
+	if err != nil {
+		return 0, err
+	}
+
+	longest := 0
+	ending := make([]int, len(strict))
+	for _, x := range linearize(strict) {
+		for _, y := range BottomIntersection(strict, x) {
+			ending[y] = max(ending[y], ending[x]+1)
+			longest = max(longest, ending[y])
+		}
+	}
+	return longest, nil
+}
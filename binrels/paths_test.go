@@ -0,0 +1,53 @@
+package binrels
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPathQueries(t *testing.T) {
+	// 0 → 1 → 2 → 0 and 2 → 3
+	a := ZeroRect(4, 4)
+	a[0][1], a[1][2], a[2][0], a[2][3] = true, true, true, true
+
+	if chain, ok := ShortestChain(a, 0, 3); !ok || !slices.Equal(chain, []int{0, 1, 2, 3}) {
+		t.Errorf("ShortestChain(a, 0, 3) = %v, %v", chain, ok)
+	}
+	if _, ok := ShortestChain(a, 3, 0); ok {
+		t.Errorf("ShortestChain(a, 3, 0) found a chain")
+	}
+
+	lengths := PathLengths(a)
+	if lengths[0][0] != 3 || lengths[1][0] != 2 || lengths[3][0] != -1 {
+		t.Errorf("PathLengths(a) = %v", lengths)
+	}
+
+	if cycle, ok := FindCycle(a); !ok || len(cycle) != 3 {
+		t.Errorf("FindCycle(a) = %v, %v", cycle, ok)
+	}
+	if _, err := LongestChain(a); err == nil {
+		t.Errorf("LongestChain of a cyclic relation succeeded")
+	}
+
+	a[2][0] = false
+	if n, err := LongestChain(ReflexiveClosure(a)); err != nil || n != 3 {
+		t.Errorf("LongestChain = %d, %v, want 3", n, err)
+	}
+}
+
+func TestPathQueriesRectangular(t *testing.T) {
+	a := [][]bool{{false, false, true}, {false, false, false}}
+
+	if chain, ok := ShortestChain(a, 0, 1); ok {
+		t.Errorf("ShortestChain on a 2x3 relation = %v", chain)
+	}
+	if lengths := PathLengths(a); lengths != nil {
+		t.Errorf("PathLengths on a 2x3 relation = %v", lengths)
+	}
+	if cycle, ok := FindCycle(a); ok {
+		t.Errorf("FindCycle on a 2x3 relation = %v", cycle)
+	}
+	if _, err := LongestChain(a); err == nil {
+		t.Errorf("LongestChain on a 2x3 relation succeeded")
+	}
+}
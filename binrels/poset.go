@@ -0,0 +1,172 @@
+package binrels
+
+// Poset answers order-theoretic queries about a partial order. Loops are
+// implied, so a strict order describes the same poset as its reflexive
+// closure.
+type Poset struct {
+	le [][]bool
+}
+
+func NewPoset(a [][]bool) (*Poset, error) {
+	n, err := checkSquare("NewPoset", a)
+	if err != nil {
+		return nil, err
+	}
+
+	le := Zero(0)
+	if n > 0 {
+		le = ReflexiveClosure(a)
+	}
+
+	if err = require("NewPoset", le, Antisymmetric, Transitive); err != nil {
+		return nil, err
+	}
+	return &Poset{le: le}, nil
+}
+
+func (p *Poset) Size() int {
+	return len(p.le)
+}
+
+// Matrix returns the reflexive order relation.
+func (p *Poset) Matrix() [][]bool {
+	return Copy(p.le)
+}
+
+func (p *Poset) Less(x, y int) bool {
+	return x != y && p.LessOrEqual(x, y)
+}
+
+func (p *Poset) LessOrEqual(x, y int) bool {
+	if x < 0 || x >= len(p.le) || y < 0 || y >= len(p.le) {
+		return false
+	}
+	return p.le[x][y]
+}
+
+// Minimal returns the elements with nothing strictly below them.
+func (p *Poset) Minimal() []int {
+	res := make([]int, 0)
+	for x := range p.le {
+		if len(TopIntersection(p.le, x)) == 1 {
+			res = append(res, x)
+		}
+	}
+	return res
+}
+
+// Maximal returns the elements with nothing strictly above them.
+func (p *Poset) Maximal() []int {
+	res := make([]int, 0)
+	for x := range p.le {
+		if len(BottomIntersection(p.le, x)) == 1 {
+			res = append(res, x)
+		}
+	}
+	return res
+}
+
+func (p *Poset) Least() (int, bool) {
+	return p.least(p.all())
+}
+
+func (p *Poset) Greatest() (int, bool) {
+	return p.greatest(p.all())
+}
+
+func (p *Poset) all() []int {
+	res := make([]int, len(p.le))
+	for x := range res {
+		res[x] = x
+	}
+	return res
+}
+
+func (p *Poset) least(set []int) (int, bool) {
+	for _, x := range set {
+		below := true
+		for _, y := range set {
+			if !p.le[x][y] {
+				below = false
+				break
+			}
+		}
+		if below {
+			return x, true
+		}
+	}
+	return -1, false
+}
+
+func (p *Poset) greatest(set []int) (int, bool) {
+	for _, x := range set {
+		above := true
+		for _, y := range set {
+			if !p.le[y][x] {
+				above = false
+				break
+			}
+		}
+		if above {
+			return x, true
+		}
+	}
+	return -1, false
+}
+
+func (p *Poset) inRange(subset []int) bool {
+	for _, x := range subset {
+		if x < 0 || x >= len(p.le) {
+			return false
+		}
+	}
+	return true
+}
+
+// UpperBounds returns the elements that are greater than or equal to every
+// element of subset. It returns nil when subset names an unknown element.
+func (p *Poset) UpperBounds(subset []int) []int {
+	if !p.inRange(subset) {
+		return nil
+	}
+	return common(p.le, subset, len(p.le), BottomIntersection)
+}
+
+// LowerBounds returns the elements that are less than or equal to every
+// element of subset. It returns nil when subset names an unknown element.
+func (p *Poset) LowerBounds(subset []int) []int {
+	if !p.inRange(subset) {
+		return nil
+	}
+	return common(p.le, subset, len(p.le), TopIntersection)
+}
+
+// Supremum returns the least upper bound of subset, if there is one.
+func (p *Poset) Supremum(subset []int) (int, bool) {
+	return p.least(p.UpperBounds(subset))
+}
+
+// Infimum returns the greatest lower bound of subset, if there is one.
+func (p *Poset) Infimum(subset []int) (int, bool) {
+	return p.greatest(p.LowerBounds(subset))
+}
+
+// IsLattice reports whether every pair of elements has both a supremum and
+// an infimum.
+func (p *Poset) IsLattice() bool {
+	if len(p.le) == 0 {
+		return false
+	}
+
+	for x := range p.le {
+		for y := x + 1; y < len(p.le); y++ {
+			if _, ok := p.Supremum([]int{x, y}); !ok {
+				return false
+			}
+			if _, ok := p.Infimum([]int{x, y}); !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
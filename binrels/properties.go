@@ -0,0 +1,257 @@
+package binrels
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+type Pair struct {
+	I, J int
+}
+
+type Property int
+
+const (
+	Reflexive Property = iota
+	Irreflexive
+	Symmetric
+	Antisymmetric
+	Asymmetric
+	Transitive
+	Total
+	Acyclic
+)
+
+var propertyNames = [...]string{
+	Reflexive:     "reflexive",
+	Irreflexive:   "irreflexive",
+	Symmetric:     "symmetric",
+	Antisymmetric: "antisymmetric",
+	Asymmetric:    "asymmetric",
+	Transitive:    "transitive",
+	Total:         "total",
+	Acyclic:       "acyclic",
+}
+
+func (p Property) String() string {
+	if p < 0 || int(p) >= len(propertyNames) {
+		return "unknown"
+	}
+	return propertyNames[p]
+}
+
+var propertyWitnesses = [...]func(a [][]bool) (Pair, bool){
+	Reflexive:     reflexiveWitness,
+	Irreflexive:   irreflexiveWitness,
+	Symmetric:     symmetricWitness,
+	Antisymmetric: antisymmetricWitness,
+	Asymmetric:    asymmetricWitness,
+	Transitive:    transitiveWitness,
+	Total:         totalWitness,
+	Acyclic:       acyclicWitness,
+}
+
+// Witness returns a pair that violates p in a. The second result is false
+// when a has the property. The properties are only defined for square
+// relations, so any other relation fails them all with the pair (-1, -1),
+// as does every relation for an unknown property.
+func Witness(a [][]bool, p Property) (Pair, bool) {
+	if p < 0 || int(p) >= len(propertyWitnesses) {
+		return Pair{-1, -1}, true
+	}
+	if _, err := checkSquare("Witness", a); err != nil {
+		return Pair{-1, -1}, true
+	}
+	return propertyWitnesses[p](a)
+}
+
+func Has(a [][]bool, p Property) bool {
+	_, failed := Witness(a, p)
+	return !failed
+}
+
+func IsReflexive(a [][]bool) bool     { return Has(a, Reflexive) }
+func IsIrreflexive(a [][]bool) bool   { return Has(a, Irreflexive) }
+func IsSymmetric(a [][]bool) bool     { return Has(a, Symmetric) }
+func IsAntisymmetric(a [][]bool) bool { return Has(a, Antisymmetric) }
+func IsAsymmetric(a [][]bool) bool    { return Has(a, Asymmetric) }
+func IsTransitive(a [][]bool) bool    { return Has(a, Transitive) }
+func IsTotal(a [][]bool) bool         { return Has(a, Total) }
+func IsAcyclic(a [][]bool) bool       { return Has(a, Acyclic) }
+
+// PropertyError reports that a relation lacks a property an operation
+// requires, together with the pair that violates it.
+type PropertyError struct {
+	Property Property
+	Witness  Pair
+}
+
+func (e *PropertyError) Error() string {
+	return fmt.Sprintf("relation is not %s: witness (%d, %d)", e.Property, e.Witness.I, e.Witness.J)
+}
+
+// require checks that a is square and has every one of props. Op names the
+// caller in the error for a relation that is not square.
+func require(op string, a [][]bool, props ...Property) error {
+	if _, err := checkSquare(op, a); err != nil {
+		return err
+	}
+	for _, p := range props {
+		if w, failed := Witness(a, p); failed {
+			return &PropertyError{Property: p, Witness: w}
+		}
+	}
+	return nil
+}
+
+func findcell(size int, f func(i, j int) bool) (Pair, bool) {
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			if f(i, j) {
+				return Pair{i, j}, true
+			}
+		}
+	}
+	return Pair{}, false
+}
+
+func reflexiveWitness(a [][]bool) (Pair, bool) {
+	for i := range a {
+		if !a[i][i] {
+			return Pair{i, i}, true
+		}
+	}
+	return Pair{}, false
+}
+
+func irreflexiveWitness(a [][]bool) (Pair, bool) {
+	for i := range a {
+		if a[i][i] {
+			return Pair{i, i}, true
+		}
+	}
+	return Pair{}, false
+}
+
+func symmetricWitness(a [][]bool) (Pair, bool) {
+	return findcell(len(a), func(i, j int) bool {
+		return a[i][j] && !a[j][i]
+	})
+}
+
+func antisymmetricWitness(a [][]bool) (Pair, bool) {
+	return findcell(len(a), func(i, j int) bool {
+		return i != j && a[i][j] && a[j][i]
+	})
+}
+
+func asymmetricWitness(a [][]bool) (Pair, bool) {
+	return findcell(len(a), func(i, j int) bool {
+		return a[i][j] && a[j][i]
+	})
+}
+
+func totalWitness(a [][]bool) (Pair, bool) {
+	return findcell(len(a), func(i, j int) bool {
+		return !a[i][j] && !a[j][i]
+	})
+}
+
+// transitiveWitness reports a pair of R∘R that is missing from R.
+func transitiveWitness(a [][]bool) (Pair, bool) {
+	if len(a) == 0 {
+		return Pair{}, false
+	}
+
+	r := FromMatrix(a)
+	return firstPair(r.Composition(r).Diff(r))
+}
+
+// acyclicWitness reports a pair (i, j) of R such that i is reachable back
+// from j.
+func acyclicWitness(a [][]bool) (Pair, bool) {
+	closure := TransitiveClosure(a)
+	return findcell(len(a), func(i, j int) bool {
+		return a[i][j] && closure[j][i]
+	})
+}
+
+func firstPair(r *Relation) (Pair, bool) {
+	for i := 0; i < r.rows; i++ {
+		for w, word := range r.row(i) {
+			if word != 0 {
+				return Pair{i, w*wordBits + bits.TrailingZeros64(word)}, true
+			}
+		}
+	}
+	return Pair{}, false
+}
+
+type Classification struct {
+	Equivalence  bool
+	PartialOrder bool
+	StrictOrder  bool
+	LinearOrder  bool
+	Preorder     bool
+	Tolerance    bool
+
+	// Failed holds a witness pair for every property the relation lacks.
+	Failed map[Property]Pair
+}
+
+func (c Classification) Holds(p Property) bool {
+	_, failed := c.Failed[p]
+	return !failed
+}
+
+func (c Classification) String() string {
+	kinds := make([]string, 0, 6)
+	for _, k := range []struct {
+		ok   bool
+		name string
+	}{
+		{c.Equivalence, "equivalence"},
+		{c.PartialOrder, "partial order"},
+		{c.StrictOrder, "strict order"},
+		{c.LinearOrder, "linear order"},
+		{c.Preorder, "preorder"},
+		{c.Tolerance, "tolerance"},
+	} {
+		if k.ok {
+			kinds = append(kinds, k.name)
+		}
+	}
+
+	if len(kinds) == 0 {
+		return "unclassified"
+	}
+	return strings.Join(kinds, ", ")
+}
+
+// Classify tests every property of a at once. It returns an error for a
+// relation that is not square.
+func Classify(a [][]bool) (Classification, error) {
+	if _, err := checkSquare("Classify", a); err != nil {
+		return Classification{}, err
+	}
+
+	c := Classification{Failed: make(map[Property]Pair)}
+	for p, witness := range propertyWitnesses {
+		if w, failed := witness(a); failed {
+			c.Failed[Property(p)] = w
+		}
+	}
+
+	reflexive := c.Holds(Reflexive)
+	transitive := c.Holds(Transitive)
+
+	c.Preorder = reflexive && transitive
+	c.Tolerance = reflexive && c.Holds(Symmetric)
+	c.Equivalence = c.Preorder && c.Holds(Symmetric)
+	c.PartialOrder = c.Preorder && c.Holds(Antisymmetric)
+	c.StrictOrder = c.Holds(Irreflexive) && transitive
+	c.LinearOrder = c.PartialOrder && c.Holds(Total)
+
+	return c, nil
+}
@@ -0,0 +1,58 @@
+package binrels
+
+import "testing"
+
+func TestWitness(t *testing.T) {
+	// 0 → 1 → 2 with loops on 0 and 1
+	a := Zero(3)
+	a[0][0], a[1][1], a[0][1], a[1][2] = true, true, true, true
+
+	tests := []struct {
+		p       Property
+		witness Pair
+		failed  bool
+	}{
+		{Reflexive, Pair{2, 2}, true},
+		{Irreflexive, Pair{0, 0}, true},
+		{Symmetric, Pair{0, 1}, true},
+		{Antisymmetric, Pair{}, false},
+		{Asymmetric, Pair{0, 0}, true},
+		{Transitive, Pair{0, 2}, true},
+		{Total, Pair{0, 2}, true},
+		{Acyclic, Pair{0, 0}, true},
+		{Property(99), Pair{-1, -1}, true},
+		{Property(-1), Pair{-1, -1}, true},
+	}
+	for _, tt := range tests {
+		w, failed := Witness(a, tt.p)
+		if failed != tt.failed || failed && w != tt.witness {
+			t.Errorf("Witness(a, %v) = %v, %v, want %v, %v", tt.p, w, failed, tt.witness, tt.failed)
+		}
+	}
+
+	if Has(a, Property(99)) {
+		t.Errorf("Has reports an unknown property")
+	}
+}
+
+func TestWitnessNonSquare(t *testing.T) {
+	tall := [][]bool{{true, false}, {false, true}, {true, true}}
+	for p := Reflexive; p <= Acyclic; p++ {
+		if w, failed := Witness(tall, p); !failed || w != (Pair{-1, -1}) {
+			t.Errorf("Witness of a 3x2 relation for %v = %v, %v", p, w, failed)
+		}
+	}
+	if _, err := Classify(tall); err == nil {
+		t.Errorf("Classify of a 3x2 relation succeeded")
+	}
+}
+
+func TestClassify(t *testing.T) {
+	c, err := Classify(Reachability(chain()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.PartialOrder || !c.LinearOrder || c.Equivalence || c.StrictOrder {
+		t.Errorf("Classify of a chain = %v", c)
+	}
+}
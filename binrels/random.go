@@ -0,0 +1,114 @@
+package binrels
+
+import "math/rand/v2"
+
+// The generators draw everything from rng, so a seeded source such as
+// rand.New(rand.NewPCG(seed, 0)) reproduces the same relations. They return
+// nil for invalid sizes or densities outside [0, 1].
+
+func validDensity(density float64) bool {
+	return density >= 0 && density <= 1
+}
+
+// RandomRelation includes every pair independently with probability density.
+func RandomRelation(rng *rand.Rand, n int, density float64) [][]bool {
+	if n < 0 || !validDensity(density) {
+		return nil
+	}
+
+	return foreachcell(n, n, func(i, j int) bool {
+		return rng.Float64() < density
+	})
+}
+
+// RandomEquivalence returns an equivalence relation with exactly k classes.
+func RandomEquivalence(rng *rand.Rand, n, k int) [][]bool {
+	if n < 0 || k < 0 || k > n || (k == 0) != (n == 0) {
+		return nil
+	}
+
+	// The first k elements of a shuffle seed one class each so that no class
+	// stays empty; the rest join a random class.
+	perm := rng.Perm(n)
+	classOf := make([]int, n)
+	for i, x := range perm {
+		if i < k {
+			classOf[x] = i
+		} else {
+			classOf[x] = rng.IntN(k)
+		}
+	}
+
+	return foreachcell(n, n, func(i, j int) bool {
+		return classOf[i] == classOf[j]
+	})
+}
+
+// RandomDAG returns an acyclic, irreflexive relation. Elements are placed in
+// a random order and each forward pair is kept with probability density.
+func RandomDAG(rng *rand.Rand, n int, density float64) [][]bool {
+	if n < 0 || !validDensity(density) {
+		return nil
+	}
+
+	perm := rng.Perm(n)
+	result := Zero(n)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if rng.Float64() < density {
+				result[perm[i]][perm[j]] = true
+			}
+		}
+	}
+	return result
+}
+
+// RandomPartialOrder returns the reflexive transitive closure of a random
+// DAG, which is a reflexive partial order.
+func RandomPartialOrder(rng *rand.Rand, n int, density float64) [][]bool {
+	dag := RandomDAG(rng, n, density)
+	if dag == nil {
+		return nil
+	}
+	if n == 0 {
+		return dag
+	}
+	return Reachability(dag)
+}
+
+// RandomLinearOrder returns a reflexive total order on a random permutation
+// of the elements.
+func RandomLinearOrder(rng *rand.Rand, n int) [][]bool {
+	if n < 0 {
+		return nil
+	}
+
+	rank := make([]int, n)
+	for i, x := range rng.Perm(n) {
+		rank[x] = i
+	}
+
+	return foreachcell(n, n, func(i, j int) bool {
+		return rank[i] <= rank[j]
+	})
+}
+
+// RandomTournament orients every pair of distinct elements in exactly one
+// direction at random.
+func RandomTournament(rng *rand.Rand, n int) [][]bool {
+	if n < 0 {
+		return nil
+	}
+
+	result := Zero(n)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if rng.IntN(2) == 0 {
+				result[i][j] = true
+			} else {
+				result[j][i] = true
+			}
+		}
+	}
+	return result
+}
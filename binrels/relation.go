@@ -0,0 +1,323 @@
+package binrels
+
+import "math/bits"
+
+const wordBits = 64
+
+// Relation is a binary relation stored as a bit matrix: every row is packed
+// into uint64 words, so a pair costs one bit instead of one byte.
+type Relation struct {
+	rows   int
+	cols   int
+	stride int
+	words  []uint64
+}
+
+func newRelation(rows, cols int) *Relation {
+	stride := (cols + wordBits - 1) / wordBits
+	return &Relation{
+		rows:   rows,
+		cols:   cols,
+		stride: stride,
+		words:  make([]uint64, rows*stride),
+	}
+}
+
+func NewRelation(n int) *Relation {
+	if n < 0 {
+		return nil
+	}
+	return newRelation(n, n)
+}
+
+// NewRectRelation returns an empty relation between a set of rows elements
+// and a set of cols elements.
+func NewRectRelation(rows, cols int) *Relation {
+	if rows < 0 || cols < 0 {
+		return nil
+	}
+	return newRelation(rows, cols)
+}
+
+func IdentityRelation(n int) *Relation {
+	r := NewRelation(n)
+	if r == nil {
+		return nil
+	}
+	for i := 0; i < n; i++ {
+		r.Add(i, i)
+	}
+	return r
+}
+
+func FromMatrix(a [][]bool) *Relation {
+	cols := 0
+	if len(a) > 0 {
+		cols = len(a[0])
+	}
+
+	r := newRelation(len(a), cols)
+	for i := range a {
+		row := r.row(i)
+		for j := 0; j < len(a[i]) && j < r.cols; j++ {
+			if a[i][j] {
+				row[j/wordBits] |= 1 << (j % wordBits)
+			}
+		}
+	}
+	return r
+}
+
+func (r *Relation) Matrix() [][]bool {
+	if r == nil || r.rows == 0 {
+		return nil
+	}
+
+	matrix := make([][]bool, r.rows)
+	for i := range matrix {
+		matrix[i] = make([]bool, r.cols)
+		row := r.row(i)
+		for j := range matrix[i] {
+			matrix[i][j] = row[j/wordBits]&(1<<(j%wordBits)) != 0
+		}
+	}
+	return matrix
+}
+
+func (r *Relation) row(i int) []uint64 {
+	return r.words[i*r.stride : (i+1)*r.stride]
+}
+
+// tailMask keeps only the bits of the last word in a row that belong to
+// actual columns.
+func (r *Relation) tailMask() uint64 {
+	if rem := r.cols % wordBits; rem != 0 {
+		return 1<<rem - 1
+	}
+	return ^uint64(0)
+}
+
+func (r *Relation) inRange(i, j int) bool {
+	return i >= 0 && i < r.rows && j >= 0 && j < r.cols
+}
+
+func (r *Relation) Size() int {
+	return r.rows
+}
+
+func (r *Relation) Rows() int {
+	return r.rows
+}
+
+func (r *Relation) Cols() int {
+	return r.cols
+}
+
+func (r *Relation) Has(i, j int) bool {
+	if !r.inRange(i, j) {
+		return false
+	}
+	return r.words[i*r.stride+j/wordBits]&(1<<(j%wordBits)) != 0
+}
+
+func (r *Relation) Add(i, j int) {
+	if !r.inRange(i, j) {
+		return
+	}
+	r.words[i*r.stride+j/wordBits] |= 1 << (j % wordBits)
+}
+
+func (r *Relation) Remove(i, j int) {
+	if !r.inRange(i, j) {
+		return
+	}
+	r.words[i*r.stride+j/wordBits] &^= 1 << (j % wordBits)
+}
+
+// Count returns the number of pairs in the relation.
+func (r *Relation) Count() int {
+	count := 0
+	for _, w := range r.words {
+		count += bits.OnesCount64(w)
+	}
+	return count
+}
+
+func (r *Relation) Clone() *Relation {
+	c := newRelation(r.rows, r.cols)
+	copy(c.words, r.words)
+	return c
+}
+
+func (r *Relation) sameShape(s *Relation) bool {
+	return s != nil && r.rows == s.rows && r.cols == s.cols
+}
+
+func (r *Relation) Equal(s *Relation) bool {
+	if !r.sameShape(s) {
+		return false
+	}
+
+	for i := range r.words {
+		if r.words[i] != s.words[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Relation) foreachword(s *Relation, f func(a, b uint64) uint64) *Relation {
+	if !r.sameShape(s) {
+		return nil
+	}
+
+	result := newRelation(r.rows, r.cols)
+	for i := range r.words {
+		result.words[i] = f(r.words[i], s.words[i])
+	}
+	return result
+}
+
+func (r *Relation) Union(s *Relation) *Relation {
+	return r.foreachword(s, func(a, b uint64) uint64 {
+		return a | b
+	})
+}
+
+func (r *Relation) Intersection(s *Relation) *Relation {
+	return r.foreachword(s, func(a, b uint64) uint64 {
+		return a & b
+	})
+}
+
+func (r *Relation) Diff(s *Relation) *Relation {
+	return r.foreachword(s, func(a, b uint64) uint64 {
+		return a &^ b
+	})
+}
+
+func (r *Relation) SymmDiff(s *Relation) *Relation {
+	return r.foreachword(s, func(a, b uint64) uint64 {
+		return a ^ b
+	})
+}
+
+func (r *Relation) Complement() *Relation {
+	result := newRelation(r.rows, r.cols)
+	mask := r.tailMask()
+	for i := 0; i < r.rows; i++ {
+		src, dst := r.row(i), result.row(i)
+		for w := range src {
+			dst[w] = ^src[w]
+		}
+		if len(dst) > 0 {
+			dst[len(dst)-1] &= mask
+		}
+	}
+	return result
+}
+
+func (r *Relation) Transpose() *Relation {
+	result := newRelation(r.cols, r.rows)
+	for i := 0; i < r.rows; i++ {
+		r.eachInRow(i, func(j int) {
+			result.Add(j, i)
+		})
+	}
+	return result
+}
+
+// Composition picks between the row-OR and the Four Russians kernels by
+// their estimated cost; both produce the same relation.
+func (r *Relation) Composition(s *Relation) *Relation {
+	if s == nil || r.cols != s.rows {
+		return nil
+	}
+
+	result := newRelation(r.rows, s.cols)
+	if preferFourRussians(r, s) {
+		composeFourRussians(result, r, s)
+	} else {
+		composeRows(result, r, s)
+	}
+	return result
+}
+
+func (r *Relation) Power(n int) *Relation {
+	if n < 0 || r.rows != r.cols {
+		return nil
+	}
+
+	if n == 0 {
+		return IdentityRelation(r.rows)
+	}
+
+	if n == 1 {
+		return r.Clone()
+	}
+
+	if n&1 == 0 {
+		half := r.Power(n / 2)
+		return half.Composition(half)
+	}
+
+	return r.Composition(r.Power(n - 1))
+}
+
+func (r *Relation) eachInRow(i int, f func(j int)) {
+	for w, word := range r.row(i) {
+		for word != 0 {
+			f(w*wordBits + bits.TrailingZeros64(word))
+			word &= word - 1
+		}
+	}
+}
+
+func orInto(dst, src []uint64) {
+	for w := range src {
+		dst[w] |= src[w]
+	}
+}
+
+// TransitiveClosure runs Warshall's algorithm on packed rows: once k is
+// allowed as an intermediate element, every row that reaches k absorbs
+// row k. This costs O(n³/64) word operations.
+func (r *Relation) TransitiveClosure() *Relation {
+	if r.rows != r.cols {
+		return nil
+	}
+
+	result := r.Clone()
+	for k := 0; k < result.rows; k++ {
+		via := result.row(k)
+		word, bit := k/wordBits, uint64(1)<<(k%wordBits)
+		for i := 0; i < result.rows; i++ {
+			row := result.row(i)
+			if row[word]&bit != 0 {
+				orInto(row, via)
+			}
+		}
+	}
+	return result
+}
+
+func (r *Relation) Reachability() *Relation {
+	closure := r.TransitiveClosure()
+	if closure == nil {
+		return nil
+	}
+
+	for i := 0; i < closure.rows; i++ {
+		closure.Add(i, i)
+	}
+	return closure
+}
+
+func (r *Relation) MutualReachability() *Relation {
+	reach := r.Reachability()
+	if reach == nil {
+		return nil
+	}
+
+	return reach.Intersection(reach.Transpose())
+}
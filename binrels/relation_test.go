@@ -0,0 +1,105 @@
+package binrels
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+var relationSizes = []int{1, 2, 63, 64, 65, 130}
+
+func TestRelationMatrixRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 1))
+	for trial := 0; trial < 100; trial++ {
+		rows := relationSizes[rng.IntN(len(relationSizes))]
+		cols := relationSizes[rng.IntN(len(relationSizes))]
+		a := randomMatrix(rng, rows, cols, rng.Float64())
+
+		r := FromMatrix(a)
+		if r.Rows() != rows || r.Cols() != cols {
+			t.Fatalf("FromMatrix of a %dx%d matrix is %dx%d", rows, cols, r.Rows(), r.Cols())
+		}
+		if got := r.Matrix(); !Equal(got, a) {
+			t.Fatalf("Matrix(FromMatrix(a)) differs from a for %dx%d", rows, cols)
+		}
+
+		count := 0
+		for i := range a {
+			for j := range a[i] {
+				if a[i][j] {
+					count++
+				}
+				if r.Has(i, j) != a[i][j] {
+					t.Fatalf("Has(%d, %d) = %v, want %v", i, j, r.Has(i, j), a[i][j])
+				}
+			}
+		}
+		if r.Count() != count {
+			t.Fatalf("Count() = %d, want %d", r.Count(), count)
+		}
+	}
+}
+
+func TestRelationWordOperations(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	binary := []struct {
+		name   string
+		packed func(r, s *Relation) *Relation
+		plain  func(a, b [][]bool) [][]bool
+	}{
+		{"Union", (*Relation).Union, Union},
+		{"Intersection", (*Relation).Intersection, Intersection},
+		{"Diff", (*Relation).Diff, Diff},
+		{"SymmDiff", (*Relation).SymmDiff, SymmDiff},
+	}
+
+	for trial := 0; trial < 100; trial++ {
+		rows := relationSizes[rng.IntN(len(relationSizes))]
+		cols := relationSizes[rng.IntN(len(relationSizes))]
+		a := randomMatrix(rng, rows, cols, rng.Float64())
+		b := randomMatrix(rng, rows, cols, rng.Float64())
+		r, s := FromMatrix(a), FromMatrix(b)
+
+		for _, op := range binary {
+			if got := op.packed(r, s).Matrix(); !Equal(got, op.plain(a, b)) {
+				t.Fatalf("%s differs from the matrix version for %dx%d", op.name, rows, cols)
+			}
+		}
+		if got := r.Complement().Matrix(); !Equal(got, Complement(a)) {
+			t.Fatalf("Complement differs from the matrix version for %dx%d", rows, cols)
+		}
+		if got := r.Transpose().Matrix(); !Equal(got, Transpose(a)) {
+			t.Fatalf("Transpose differs from the matrix version for %dx%d", rows, cols)
+		}
+		if c := r.Complement(); c.Count() != rows*cols-r.Count() {
+			t.Fatalf("Complement sets bits past the last column for %dx%d", rows, cols)
+		}
+	}
+}
+
+func TestRelationEmptyShapes(t *testing.T) {
+	for _, shape := range [][2]int{{0, 0}, {0, 3}, {3, 0}} {
+		r := NewRectRelation(shape[0], shape[1])
+		for name, got := range map[string]*Relation{
+			"Union":        r.Union(r),
+			"Intersection": r.Intersection(r),
+			"Diff":         r.Diff(r),
+			"SymmDiff":     r.SymmDiff(r),
+			"Complement":   r.Complement(),
+		} {
+			if got == nil || got.Rows() != shape[0] || got.Cols() != shape[1] {
+				t.Errorf("%s of an empty %dx%d relation = %v", name, shape[0], shape[1], got)
+			}
+		}
+
+		if got := r.Transpose(); got == nil || got.Rows() != shape[1] || got.Cols() != shape[0] {
+			t.Errorf("Transpose of an empty %dx%d relation = %v", shape[0], shape[1], got)
+		}
+		if got := r.Composition(NewRectRelation(shape[1], 2)); got == nil || got.Rows() != shape[0] || got.Cols() != 2 {
+			t.Errorf("Composition of an empty %dx%d relation = %v", shape[0], shape[1], got)
+		}
+	}
+
+	if got := NewRelation(2).Union(NewRelation(3)); got != nil {
+		t.Errorf("Union of mismatched shapes = %v, want nil", got)
+	}
+}
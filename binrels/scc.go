@@ -0,0 +1,100 @@
+package binrels
+
+import "slices"
+
+// StronglyConnectedComponents groups the elements that are mutually
+// reachable, using Tarjan's algorithm. Components are ordered by their
+// smallest element and list their elements in ascending order. It returns
+// nil for relations that are not square.
+func StronglyConnectedComponents(a [][]bool) [][]int {
+	n, err := checkSquare("StronglyConnectedComponents", a)
+	if err != nil {
+		return nil
+	}
+
+	index := make([]int, n)
+	low := make([]int, n)
+	onStack := make([]bool, n)
+	for x := range index {
+		index[x] = -1
+	}
+
+	stack := make([]int, 0, n)
+	components := make([][]int, 0)
+	next := 0
+
+	var connect func(x int)
+	connect = func(x int) {
+		index[x] = next
+		low[x] = next
+		next++
+		stack = append(stack, x)
+		onStack[x] = true
+
+		for _, y := range BottomIntersection(a, x) {
+			if index[y] == -1 {
+				connect(y)
+				low[x] = min(low[x], low[y])
+			} else if onStack[y] {
+				low[x] = min(low[x], index[y])
+			}
+		}
+
+		if low[x] != index[x] {
+			return
+		}
+
+		component := make([]int, 0)
+		for {
+			y := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[y] = false
+			component = append(component, y)
+			if y == x {
+				break
+			}
+		}
+		slices.Sort(component)
+		components = append(components, component)
+	}
+
+	for x := 0; x < n; x++ {
+		if index[x] == -1 {
+			connect(x)
+		}
+	}
+
+	slices.SortFunc(components, func(p, q []int) int {
+		return p[0] - q[0]
+	})
+	return components
+}
+
+// Condensation collapses every strongly connected component of a into a
+// single element. The result relates component p to component q (p ≠ q)
+// when a relates some element of p to some element of q, which is always
+// acyclic. Component indices follow the returned components. It returns nil
+// for relations that are not square.
+func Condensation(a [][]bool) ([][]bool, [][]int) {
+	components := StronglyConnectedComponents(a)
+	if components == nil {
+		return nil, nil
+	}
+
+	componentOf := make([]int, len(a))
+	for p, component := range components {
+		for _, x := range component {
+			componentOf[x] = p
+		}
+	}
+
+	result := Zero(len(components))
+	for x := range a {
+		for _, y := range BottomIntersection(a, x) {
+			if p, q := componentOf[x], componentOf[y]; p != q {
+				result[p][q] = true
+			}
+		}
+	}
+	return result, components
+}
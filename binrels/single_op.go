@@ -1,8 +1,11 @@
 package binrels
 
-import "slices"
-
+// Power composes a with itself n times by repeated squaring.
 func Power(a [][]bool, n int) [][]bool {
+	if n < 0 || len(a) != 0 && len(a) != len(a[0]) {
+		return nil
+	}
+
 	if n == 0 {
 		return Identity(len(a))
 	}
@@ -11,12 +14,11 @@ func Power(a [][]bool, n int) [][]bool {
 		return a
 	}
 
-	if n&1 == 0 {
-		half := Power(a, n/2)
-		return Composition(half, half)
+	if len(a) == 0 {
+		return nil
 	}
 
-	return Composition(a, Power(a, n-1))
+	return FromMatrix(a).Power(n).Matrix()
 }
 
 func Transpose(a [][]bool) [][]bool {
@@ -24,7 +26,7 @@ func Transpose(a [][]bool) [][]bool {
 		return nil
 	}
 
-	return foreachcell(len(a), func(i int, j int) bool {
+	return foreachcell(len(a[0]), len(a), func(i int, j int) bool {
 		return a[j][i]
 	})
 }
@@ -34,7 +36,7 @@ func Complement(a [][]bool) [][]bool {
 		return nil
 	}
 
-	return foreachcell(len(a), func(i int, j int) bool {
+	return foreachcell(len(a), len(a[0]), func(i int, j int) bool {
 		return !a[i][j]
 	})
 }
@@ -47,7 +49,7 @@ func DefinitionDomain(a [][]bool) []int {
 	res := make([]int, 0, len(a))
 	for i := range a {
 		for j := range a[0] {
-			if a[i][j] && !slices.Contains(res, i) {
+			if a[i][j] {
 				res = append(res, i)
 				break
 			}
@@ -61,10 +63,10 @@ func MeaningDomain(a [][]bool) []int {
 		return nil
 	}
 
-	res := make([]int, 0, len(a))
-	for i := range a {
-		for j := range a[0] {
-			if a[i][j] && !slices.Contains(res, j) {
+	res := make([]int, 0, len(a[0]))
+	for j := range a[0] {
+		for i := range a {
+			if a[i][j] {
 				res = append(res, j)
 				break
 			}
@@ -87,7 +89,7 @@ func BottomIntersection(a [][]bool, x int) []int {
 }
 
 func TopIntersection(a [][]bool, x int) []int {
-	if len(a) == 0 || x < 0 || x >= len(a) {
+	if len(a) == 0 || x < 0 || x >= len(a[0]) {
 		return nil
 	}
 	res := make([]int, 0, len(a))
@@ -99,32 +101,28 @@ func TopIntersection(a [][]bool, x int) []int {
 	return res
 }
 
+// TransitiveClosure runs Warshall's algorithm.
 func TransitiveClosure(a [][]bool) [][]bool {
 	if len(a) == 0 {
 		return nil
 	}
 
-	accumulator := Copy(a)
-	for i := 2; ; i++ {
-		next_a := Power(a, i)
-
-		before := Copy(accumulator)
-		accumulator = Union(accumulator, next_a)
-
-		if Equal(before, accumulator) {
-			break
-		}
-	}
-
-	return accumulator
+	return FromMatrix(a).TransitiveClosure().Matrix()
 }
 
+// Reachability is the reflexive transitive closure.
 func Reachability(a [][]bool) [][]bool {
-	return Union(Identity(len(a)), TransitiveClosure(a))
+	if len(a) == 0 {
+		return nil
+	}
+
+	return FromMatrix(a).Reachability().Matrix()
 }
 
 func MutualReachability(a [][]bool) [][]bool {
-	reach := Reachability(a)
+	if len(a) == 0 {
+		return nil
+	}
 
-	return Intersection(reach, Transpose(reach))
+	return FromMatrix(a).MutualReachability().Matrix()
 }
@@ -0,0 +1,219 @@
+package binrels
+
+import (
+	"fmt"
+	"iter"
+	"strconv"
+	"strings"
+)
+
+// CycleError reports a cycle x0 → x1 → … → x0 that prevents an order from
+// being linearized.
+type CycleError struct {
+	Cycle []int
+}
+
+func (e *CycleError) Error() string {
+	parts := make([]string, 0, len(e.Cycle)+1)
+	for _, x := range e.Cycle {
+		parts = append(parts, strconv.Itoa(x))
+	}
+	if len(e.Cycle) > 0 {
+		parts = append(parts, strconv.Itoa(e.Cycle[0]))
+	}
+	return "relation contains a cycle: " + strings.Join(parts, " -> ")
+}
+
+// findCycle returns the elements of some cycle of a in order, or nil when a
+// is acyclic.
+func findCycle(a [][]bool) []int {
+	const (
+		unvisited = iota
+		active
+		done
+	)
+
+	state := make([]int, len(a))
+	stack := make([]int, 0, len(a))
+
+	var visit func(x int) []int
+	visit = func(x int) []int {
+		state[x] = active
+		stack = append(stack, x)
+		for _, y := range BottomIntersection(a, x) {
+			switch state[y] {
+			case active:
+				for i := len(stack) - 1; i >= 0; i-- {
+					if stack[i] == y {
+						return append([]int(nil), stack[i:]...)
+					}
+				}
+			case unvisited:
+				if cycle := visit(y); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[x] = done
+		return nil
+	}
+
+	for x := range a {
+		if state[x] == unvisited {
+			if cycle := visit(x); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// orderGraph drops the loops of a and makes sure the rest is acyclic, so
+// reflexive and strict orders are linearized alike. Op names the caller in
+// the error for a relation that is not square.
+func orderGraph(op string, a [][]bool) ([][]bool, error) {
+	if _, err := checkSquare(op, a); err != nil {
+		return nil, err
+	}
+
+	strict := strictPart(a)
+	if cycle := findCycle(strict); cycle != nil {
+		return nil, &CycleError{Cycle: cycle}
+	}
+	return strict, nil
+}
+
+func indegrees(a [][]bool) []int {
+	in := make([]int, len(a))
+	for x := range a {
+		for _, y := range BottomIntersection(a, x) {
+			in[y]++
+		}
+	}
+	return in
+}
+
+// TopologicalSort returns a linear extension of a, always choosing the
+// smallest available element first. Loops are ignored.
+func TopologicalSort(a [][]bool) ([]int, error) {
+	if len(a) == 0 {
+		return nil, nil
+	}
+
+	strict, err := orderGraph("TopologicalSort", a)
+	if err != nil {
+		return nil, err
+	}
+
+	return linearize(strict), nil
+}
+
+// linearize orders the elements of an acyclic relation, always choosing the
+// smallest available element first.
+func linearize(strict [][]bool) []int {
+	in := indegrees(strict)
+	order := make([]int, 0, len(strict))
+	used := make([]bool, len(strict))
+	for len(order) < len(strict) {
+		for x := range in {
+			if used[x] || in[x] != 0 {
+				continue
+			}
+
+			used[x] = true
+			order = append(order, x)
+			for _, y := range BottomIntersection(strict, x) {
+				in[y]--
+			}
+			break
+		}
+	}
+	return order
+}
+
+// LinearExtensions enumerates every linear extension of a in lexicographic
+// order. Each yielded slice is freshly allocated.
+func LinearExtensions(a [][]bool) (iter.Seq[[]int], error) {
+	strict, err := orderGraph("LinearExtensions", a)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(yield func([]int) bool) {
+		in := indegrees(strict)
+		used := make([]bool, len(strict))
+		order := make([]int, 0, len(strict))
+
+		var extend func() bool
+		extend = func() bool {
+			if len(order) == len(strict) {
+				return yield(append([]int(nil), order...))
+			}
+
+			for x := range strict {
+				if used[x] || in[x] != 0 {
+					continue
+				}
+
+				used[x] = true
+				order = append(order, x)
+				for _, y := range BottomIntersection(strict, x) {
+					in[y]--
+				}
+
+				more := extend()
+
+				for _, y := range BottomIntersection(strict, x) {
+					in[y]++
+				}
+				order = order[:len(order)-1]
+				used[x] = false
+
+				if !more {
+					return false
+				}
+			}
+			return true
+		}
+
+		extend()
+	}, nil
+}
+
+const maxCountElements = 20
+
+// CountLinearExtensions counts linear extensions with a dynamic program over
+// subsets of placed elements, which limits it to small orders.
+func CountLinearExtensions(a [][]bool) (int, error) {
+	if len(a) > maxCountElements {
+		return 0, fmt.Errorf("cannot count linear extensions of %d elements, limit is %d", len(a), maxCountElements)
+	}
+
+	strict, err := orderGraph("CountLinearExtensions", a)
+	if err != nil {
+		return 0, err
+	}
+
+	below := make([]uint32, len(strict))
+	for x := range strict {
+		for _, y := range TopIntersection(strict, x) {
+			below[x] |= 1 << y
+		}
+	}
+
+	count := make([]int, 1<<len(strict))
+	count[0] = 1
+	for placed := range count {
+		if count[placed] == 0 {
+			continue
+		}
+		for x := range strict {
+			bit := uint32(1) << x
+			if uint32(placed)&bit == 0 && below[x]&^uint32(placed) == 0 {
+				count[uint32(placed)|bit] += count[placed]
+			}
+		}
+	}
+	return count[len(count)-1], nil
+}
@@ -0,0 +1,224 @@
+package binrels
+
+import (
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Step is one intermediate result of a traced computation.
+type Step struct {
+	// Op names the traced function, e.g. "TransitiveClosure".
+	Op string
+	// Index counts the steps of one call from 1.
+	Index int
+	// Note tells what the step did, e.g. "via 2" or "R^4 = R^2 ∘ R^2".
+	Note string
+	// Relation is a copy of the intermediate relation after the step.
+	Relation [][]bool
+	// Added lists the pairs of Relation missing before the step.
+	Added []Pair
+}
+
+// Tracer receives the steps of TraceComposition, TracePower,
+// TraceTransitiveClosure and TraceReachability.
+type Tracer func(s Step)
+
+func newPairs(before, after [][]bool) []Pair {
+	var added []Pair
+	for i := range after {
+		for j := range after[i] {
+			if after[i][j] && !before[i][j] {
+				added = append(added, Pair{i, j})
+			}
+		}
+	}
+	return added
+}
+
+// emit sends the step from before to after and returns a copy of after to
+// compare the next step with.
+func (t Tracer) emit(op string, index int, note string, before, after [][]bool) [][]bool {
+	current := Copy(after)
+	t(Step{
+		Op:       op,
+		Index:    index,
+		Note:     note,
+		Relation: current,
+		Added:    newPairs(before, current),
+	})
+	return current
+}
+
+// TraceComposition computes Composition(a, b), building the result one
+// middle element k at a time from the empty relation and passing every
+// step to t.
+func TraceComposition(a, b [][]bool, t Tracer) [][]bool {
+	if len(a) == 0 || len(b) == 0 || len(a[0]) != len(b) {
+		return nil
+	}
+
+	result := ZeroRect(len(a), len(b[0]))
+	before := ZeroRect(len(a), len(b[0]))
+	for k := range b {
+		for i := range a {
+			if !a[i][k] {
+				continue
+			}
+			for j := range b[k] {
+				if b[k][j] {
+					result[i][j] = true
+				}
+			}
+		}
+		before = t.emit("Composition", k+1, "via "+strconv.Itoa(k), before, result)
+	}
+	return result
+}
+
+// TracePower computes Power(a, n), passing every squaring and
+// multiplication to t. Each step is compared with the power emitted before
+// it, starting from a itself.
+func TracePower(a [][]bool, n int, t Tracer) [][]bool {
+	if n < 0 || len(a) != 0 && len(a) != len(a[0]) {
+		return nil
+	}
+
+	if n == 0 {
+		return Identity(len(a))
+	}
+
+	index := 0
+	before := a
+	var power func(n int) [][]bool
+	power = func(n int) [][]bool {
+		if n == 1 {
+			return a
+		}
+
+		var result [][]bool
+		var note string
+		if n&1 == 0 {
+			half := power(n / 2)
+			result = Composition(half, half)
+			note = powerName(n) + " = " + powerName(n/2) + " ∘ " + powerName(n/2)
+		} else {
+			result = Composition(a, power(n-1))
+			note = powerName(n) + " = R ∘ " + powerName(n-1)
+		}
+
+		index++
+		before = t.emit("Power", index, note, before, result)
+		return result
+	}
+	return power(n)
+}
+
+func powerName(n int) string {
+	if n == 1 {
+		return "R"
+	}
+	return "R^" + strconv.Itoa(n)
+}
+
+// TraceTransitiveClosure runs Warshall's algorithm like TransitiveClosure,
+// passing the relation to t after each element k is allowed as an
+// intermediate.
+func TraceTransitiveClosure(a [][]bool, t Tracer) [][]bool {
+	if len(a) == 0 || len(a) != len(a[0]) {
+		return nil
+	}
+	return traceWarshall("TransitiveClosure", a, t)
+}
+
+// TraceReachability is TraceTransitiveClosure followed by a last step that
+// adds the loops.
+func TraceReachability(a [][]bool, t Tracer) [][]bool {
+	if len(a) == 0 || len(a) != len(a[0]) {
+		return nil
+	}
+
+	closure := traceWarshall("Reachability", a, t)
+	reach := Union(closure, Identity(len(a)))
+	t.emit("Reachability", len(a)+1, "∪ I", closure, reach)
+	return reach
+}
+
+func traceWarshall(op string, a [][]bool, t Tracer) [][]bool {
+	result := Copy(a)
+	before := a
+	for k := range result {
+		for i := range result {
+			if !result[i][k] {
+				continue
+			}
+			for j := range result[k] {
+				if result[k][j] {
+					result[i][j] = true
+				}
+			}
+		}
+		before = t.emit(op, k+1, "via "+strconv.Itoa(k), before, result)
+	}
+	return result
+}
+
+// TextTracer prints every step it receives in the layout of Print, or of
+// Fprint with Options, followed by the pairs it added. The first write error
+// is kept in Err and ends the output.
+type TextTracer struct {
+	W       io.Writer
+	Options FormatOptions
+	Err     error
+}
+
+func NewTextTracer(w io.Writer, opts ...FormatOptions) *TextTracer {
+	tt := &TextTracer{W: w}
+	if len(opts) > 0 {
+		tt.Options = opts[0]
+	}
+	return tt
+}
+
+func (tt *TextTracer) label(x int) string {
+	if tt.Options.Labels == nil {
+		return strconv.Itoa(x)
+	}
+	return tt.Options.Labels[x]
+}
+
+func (tt *TextTracer) colLabel(x int) string {
+	if tt.Options.ColLabels == nil {
+		return tt.label(x)
+	}
+	return tt.Options.ColLabels[x]
+}
+
+// Trace is the Tracer to pass to the Trace functions.
+func (tt *TextTracer) Trace(s Step) {
+	if tt.Err != nil {
+		return
+	}
+
+	ew := &errWriter{w: tt.W}
+	ew.printf("%s step %d: %s\n", s.Op, s.Index, s.Note)
+	if ew.err != nil {
+		tt.Err = ew.err
+		return
+	}
+	if err := Fprint(tt.W, s.Relation, tt.Options); err != nil {
+		tt.Err = err
+		return
+	}
+
+	pairs := make([]string, len(s.Added))
+	for x, p := range s.Added {
+		pairs[x] = "(" + tt.label(p.I) + ", " + tt.colLabel(p.J) + ")"
+	}
+	if len(pairs) == 0 {
+		ew.printf("added: none\n\n")
+	} else {
+		ew.printf("added: %s\n\n", strings.Join(pairs, " "))
+	}
+	tt.Err = ew.err
+}
@@ -0,0 +1,140 @@
+package binrels
+
+import (
+	"bytes"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func collect(steps *[]Step) Tracer {
+	return func(s Step) {
+		*steps = append(*steps, s)
+	}
+}
+
+// chain relates 0 → 1 → 2 → 3.
+func chain() [][]bool {
+	a := Zero(4)
+	a[0][1], a[1][2], a[2][3] = true, true, true
+	return a
+}
+
+func TestTraceTransitiveClosure(t *testing.T) {
+	var steps []Step
+	a := chain()
+	got := TraceTransitiveClosure(a, collect(&steps))
+	if !Equal(got, TransitiveClosure(a)) {
+		t.Fatalf("TraceTransitiveClosure = %v, want %v", got, TransitiveClosure(a))
+	}
+
+	wantAdded := [][]Pair{
+		nil,
+		{{0, 2}},
+		{{0, 3}, {1, 3}},
+		nil,
+	}
+	if len(steps) != len(wantAdded) {
+		t.Fatalf("got %d steps, want %d", len(steps), len(wantAdded))
+	}
+	for k, s := range steps {
+		if s.Op != "TransitiveClosure" || s.Index != k+1 || s.Note != "via "+strconv.Itoa(k) {
+			t.Errorf("step %d is %s %d %q", k, s.Op, s.Index, s.Note)
+		}
+		if !slices.Equal(s.Added, wantAdded[k]) {
+			t.Errorf("step %d added %v, want %v", k, s.Added, wantAdded[k])
+		}
+	}
+	if !Equal(steps[len(steps)-1].Relation, got) {
+		t.Errorf("last step holds %v, want the closure", steps[len(steps)-1].Relation)
+	}
+
+	// Steps are copies, so keeping them must not alias the result.
+	steps[0].Relation[0][0] = true
+	if got[0][0] {
+		t.Errorf("changing a step changed the result")
+	}
+}
+
+func TestTraceReachability(t *testing.T) {
+	var steps []Step
+	a := chain()
+	got := TraceReachability(a, collect(&steps))
+	if !Equal(got, Reachability(a)) {
+		t.Fatalf("TraceReachability = %v, want %v", got, Reachability(a))
+	}
+
+	last := steps[len(steps)-1]
+	if want := []Pair{{0, 0}, {1, 1}, {2, 2}, {3, 3}}; last.Index != 5 || !slices.Equal(last.Added, want) {
+		t.Errorf("last step %d added %v, want step 5 adding %v", last.Index, last.Added, want)
+	}
+}
+
+func TestTraceComposition(t *testing.T) {
+	var steps []Step
+	a := [][]bool{{true, true}, {false, true}}
+	b := [][]bool{{false, false, true}, {true, false, false}}
+	got := TraceComposition(a, b, collect(&steps))
+	if !Equal(got, Composition(a, b)) {
+		t.Fatalf("TraceComposition = %v, want %v", got, Composition(a, b))
+	}
+
+	wantAdded := [][]Pair{
+		{{0, 2}},
+		{{0, 0}, {1, 0}},
+	}
+	if len(steps) != len(wantAdded) {
+		t.Fatalf("got %d steps, want %d", len(steps), len(wantAdded))
+	}
+	for k, s := range steps {
+		if !slices.Equal(s.Added, wantAdded[k]) {
+			t.Errorf("step %d added %v, want %v", k, s.Added, wantAdded[k])
+		}
+	}
+
+	if TraceComposition(a, Zero(3), collect(&steps)) != nil {
+		t.Errorf("TraceComposition of mismatched shapes is not nil")
+	}
+}
+
+func TestTracePower(t *testing.T) {
+	var steps []Step
+	a := chain()
+	got := TracePower(a, 3, collect(&steps))
+	if !Equal(got, Power(a, 3)) {
+		t.Fatalf("TracePower = %v, want %v", got, Power(a, 3))
+	}
+
+	notes := make([]string, len(steps))
+	for k, s := range steps {
+		notes[k] = s.Note
+	}
+	if want := []string{"R^2 = R ∘ R", "R^3 = R ∘ R^2"}; !slices.Equal(notes, want) {
+		t.Errorf("notes = %q, want %q", notes, want)
+	}
+	if want := []Pair{{0, 3}}; !slices.Equal(steps[1].Added, want) {
+		t.Errorf("R^3 added %v, want %v", steps[1].Added, want)
+	}
+}
+
+func TestTextTracer(t *testing.T) {
+	var buf bytes.Buffer
+	tt := NewTextTracer(&buf, FormatOptions{Labels: []string{"a", "b", "c", "d"}})
+	TraceTransitiveClosure(chain(), tt.Trace)
+	if tt.Err != nil {
+		t.Fatal(tt.Err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"TransitiveClosure step 2: via 1\n",
+		"added: (a, c)\n",
+		"added: (a, d) (b, d)\n",
+		"added: none\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks %q:\n%s", want, out)
+		}
+	}
+}
@@ -120,6 +120,10 @@ func (g *Graph) drawAxes(scaleX, scaleY, plotHeight, plotWidth, xOffset, yOffset
 }
 
 func (g *Graph) Draw() error {
+	if g.gtype == RelationType {
+		return g.drawRelation()
+	}
+
 	if len(g.plots) == 0 {
 		return fmt.Errorf("no data to plot")
 	}
@@ -16,6 +16,7 @@ import (
 const (
 	GraphType = iota
 	HeatmapType
+	RelationType
 )
 
 //go:embed fonts/ArialMT.ttf
@@ -53,13 +54,14 @@ type bounds struct {
 }
 
 type Graph struct {
-	dc     *gg.Context
-	width  int
-	height int
-	plots  []Plot
-	values [][]float64
-	gtype  int
-	bounds bounds
+	dc       *gg.Context
+	width    int
+	height   int
+	plots    []Plot
+	values   [][]float64
+	relation *relationGrid
+	gtype    int
+	bounds   bounds
 }
 
 func NewGraph(w, h int) *Graph {
@@ -167,6 +169,7 @@ func (g *Graph) Clear() {
 	g.dc.SetRGB(1, 1, 1)
 	g.dc.Clear()
 	g.plots = make([]Plot, 0)
+	g.relation = nil
 	g.gtype = -1
 }
 
@@ -14,6 +14,10 @@ func (g *Graph) Plot(x, y []float64, ls *LineStyle, labels ...[]string) {
 		panic("Heatmap type already set. Cannot add plot.")
 	}
 
+	if g.gtype == RelationType {
+		panic("Relation type already set. Cannot add plot.")
+	}
+
 	if len(x) == 0 || len(y) == 0 || (len(labels) > 0 && len(labels[0]) != len(x)) || ls == nil {
 		return
 	}
@@ -0,0 +1,155 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+type relationGrid struct {
+	matrix    [][]bool
+	compare   [][]bool
+	rowLabels []string
+	colLabels []string
+}
+
+// Relation draws a boolean matrix as a black and white grid. Rows and
+// columns are numbered when their labels are nil; a square matrix with row
+// labels only uses them for the columns as well. When another matrix is
+// passed, the cells where the two differ are highlighted in red; it must have
+// the same shape, like the labels.
+func (g *Graph) Relation(matrix [][]bool, rowLabels, colLabels []string, compare ...[][]bool) {
+	if g.gtype != -1 {
+		panic("Graph type already set. Cannot add relation.")
+	}
+
+	if len(matrix) == 0 || len(matrix[0]) == 0 {
+		return
+	}
+	for _, row := range matrix {
+		if len(row) != len(matrix[0]) {
+			return
+		}
+	}
+
+	rows, cols := len(matrix), len(matrix[0])
+	if colLabels == nil && rows == cols {
+		colLabels = rowLabels
+	}
+	if rowLabels != nil && len(rowLabels) != rows {
+		panic(fmt.Sprintf("row labels must match the rows: %d != %d", len(rowLabels), rows))
+	}
+	if colLabels != nil && len(colLabels) != cols {
+		panic(fmt.Sprintf("column labels must match the columns: %d != %d", len(colLabels), cols))
+	}
+
+	var other [][]bool
+	if len(compare) > 0 {
+		other = compare[0]
+		if len(other) != rows {
+			panic(fmt.Sprintf("compared matrix must match the rows: %d != %d", len(other), rows))
+		}
+		for _, row := range other {
+			if len(row) != cols {
+				panic(fmt.Sprintf("compared matrix must match the columns: %d != %d", len(row), cols))
+			}
+		}
+	}
+
+	g.relation = &relationGrid{
+		matrix:    matrix,
+		compare:   other,
+		rowLabels: rowLabels,
+		colLabels: colLabels,
+	}
+	g.gtype = RelationType
+}
+
+func relationLabel(labels []string, i int) string {
+	if labels == nil {
+		return strconv.Itoa(i)
+	}
+	return labels[i]
+}
+
+func (r *relationGrid) cellColor(i, j int) (float64, float64, float64) {
+	set := r.matrix[i][j]
+	if r.compare != nil && r.compare[i][j] != set {
+		// dark red: only in the drawn relation, light red: only in the other
+		if set {
+			return 0.8, 0, 0
+		}
+		return 1, 0.7, 0.7
+	}
+
+	if set {
+		return 0, 0, 0
+	}
+	return 1, 1, 1
+}
+
+func (g *Graph) drawRelation() error {
+	if g.relation == nil {
+		return fmt.Errorf("no data to plot")
+	}
+
+	font, err := GetFontFace(14)
+	if err != nil {
+		return err
+	}
+	g.dc.SetFontFace(font)
+
+	rows := len(g.relation.matrix)
+	cols := len(g.relation.matrix[0])
+
+	labelWidth := 0.0
+	for i := 0; i < rows; i++ {
+		w, _ := g.dc.MeasureString(relationLabel(g.relation.rowLabels, i))
+		labelWidth = math.Max(labelWidth, w)
+	}
+	labelWidth += 10
+	labelHeight := 20.0
+
+	padding := 40.0
+	plotWidth := float64(g.width) - padding*2 - labelWidth
+	plotHeight := float64(g.height) - padding*2 - labelHeight
+	cell := math.Min(plotWidth/float64(cols), plotHeight/float64(rows))
+	if cell <= 0 {
+		return fmt.Errorf("image too small for a %dx%d relation", rows, cols)
+	}
+
+	offsetX := padding + labelWidth
+	offsetY := padding + labelHeight
+
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			R, G, B := g.relation.cellColor(i, j)
+			g.dc.SetRGB(R, G, B)
+			g.dc.DrawRectangle(offsetX+float64(j)*cell, offsetY+float64(i)*cell, cell, cell)
+			g.dc.Fill()
+		}
+	}
+
+	g.dc.SetRGB(0.6, 0.6, 0.6)
+	g.dc.SetLineWidth(1)
+	for i := 0; i <= rows; i++ {
+		y := offsetY + float64(i)*cell
+		g.dc.DrawLine(offsetX, y, offsetX+float64(cols)*cell, y)
+		g.dc.Stroke()
+	}
+	for j := 0; j <= cols; j++ {
+		x := offsetX + float64(j)*cell
+		g.dc.DrawLine(x, offsetY, x, offsetY+float64(rows)*cell)
+		g.dc.Stroke()
+	}
+
+	g.dc.SetRGB(0, 0, 0)
+	for i := 0; i < rows; i++ {
+		g.dc.DrawStringAnchored(relationLabel(g.relation.rowLabels, i), offsetX-6, offsetY+(float64(i)+0.5)*cell, 1, 0.5)
+	}
+	for j := 0; j < cols; j++ {
+		g.dc.DrawStringAnchored(relationLabel(g.relation.colLabels, j), offsetX+(float64(j)+0.5)*cell, offsetY-6, 0.5, 0)
+	}
+
+	return nil
+}